@@ -0,0 +1,212 @@
+package udecimal
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+)
+
+var errPolicy = errors.New("invalid remainder policy")
+
+// RemainderPolicy selects which parts receive the lots left over when Allocate rounds each part down to a
+// whole number of lots. There are always fewer left over lots than parts with a non-zero weight, so no
+// part receives more than one.
+type RemainderPolicy byte
+
+const (
+	// RemainderLargest gives the left over lots to the parts whose exact shares have the largest
+	// fractional remainders (the largest remainder or Hamilton method), so every part is as close as
+	// possible to its exact share
+	RemainderLargest RemainderPolicy = iota
+	// RemainderFirstN gives the left over lots to the first parts with a non-zero weight
+	RemainderFirstN
+	// RemainderRoundRobin deals the left over lots in turn to the parts with a non-zero weight, starting at a
+	// given part and wrapping around. Allocate starts at the first part, and AllocateRoundRobin starts at an
+	// offset and returns the offset to continue from, so repeated allocations do not favour the same parts.
+	RemainderRoundRobin
+	// RemainderLargestWeight gives the left over lots to the parts with the largest weights
+	RemainderLargestWeight
+)
+
+func (p RemainderPolicy) String() string {
+	switch p {
+	case RemainderLargest:
+		return "Largest"
+	case RemainderFirstN:
+		return "FirstN"
+	case RemainderRoundRobin:
+		return "RoundRobin"
+	case RemainderLargestWeight:
+		return "LargestWeight"
+	}
+	return "RemainderPolicy(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Allocate splits total into parts proportional to weights, such as a fill across accounts or a fee across
+// legs. Every part is a multiple of lot, or of the smallest unit if lot is zero, and the parts sum exactly
+// to total. Each part is first rounded down to a whole number of lots and the left over lots are then
+// distributed according to policy, with ties going to the earlier part.
+//
+// It returns ErrLotSize if total is not a multiple of lot, ErrDivisionByZero if the weights are all zero
+// and ErrOverflow if the sum of the weights is larger than MAX.
+func Allocate(total Decimal, weights []Decimal, lot Decimal, policy RemainderPolicy) ([]Decimal, error) {
+	return AllocateInto(make([]Decimal, 0, len(weights)), total, weights, lot, policy)
+}
+
+// AllocateInto allocates total as Allocate does, appending the parts to dst and returning the extended
+// slice. It does not allocate if dst has enough capacity. dst is returned unchanged on error.
+func AllocateInto(dst []Decimal, total Decimal, weights []Decimal, lot Decimal, policy RemainderPolicy) ([]Decimal, error) {
+	dst, _, err := allocate(dst, total, weights, lot, policy, 0)
+	return dst, err
+}
+
+// AllocateRoundRobin allocates total as Allocate does with RemainderRoundRobin, dealing the left over lots
+// from the part at offset start, taken modulo the number of parts. It also returns the offset after the last
+// part that received a left over lot, or start if there were none, to pass as start to the next allocation.
+func AllocateRoundRobin(total Decimal, weights []Decimal, lot Decimal, start int) ([]Decimal, int, error) {
+	return AllocateRoundRobinInto(make([]Decimal, 0, len(weights)), total, weights, lot, start)
+}
+
+// AllocateRoundRobinInto allocates total as AllocateRoundRobin does, appending the parts to dst and returning
+// the extended slice. It does not allocate if dst has enough capacity. dst is returned unchanged on error.
+func AllocateRoundRobinInto(dst []Decimal, total Decimal, weights []Decimal, lot Decimal, start int) ([]Decimal, int, error) {
+	return allocate(dst, total, weights, lot, RemainderRoundRobin, start)
+}
+
+// allocate implements AllocateInto and AllocateRoundRobinInto. offset is the part to start dealing round
+// robin lots from, and the offset to continue from is returned.
+func allocate(dst []Decimal, total Decimal, weights []Decimal, lot Decimal, policy RemainderPolicy, offset int) ([]Decimal, int, error) {
+	if policy > RemainderLargestWeight {
+		return dst, offset, errPolicy
+	}
+	unit := lot.fp
+	if unit == 0 {
+		unit = 1
+	}
+	if total.fp%unit != 0 {
+		return dst, offset, ErrLotSize
+	}
+	var sum uint64
+	for _, w := range weights {
+		var err error
+		if sum, err = addUnits(sum, w.fp); err != nil {
+			return dst, offset, err
+		}
+	}
+	if sum == 0 {
+		return dst, offset, ErrDivisionByZero
+	}
+
+	// the parts are computed in lots. lots*w/sum is at most lots, so the quotient fits in 64 bits.
+	lots := total.fp / unit
+	start := len(dst)
+	given := uint64(0)
+	for _, w := range weights {
+		hi, lo := bits.Mul64(lots, w.fp)
+		q, _ := bits.Div64(hi, lo, sum)
+		dst = append(dst, Decimal{fp: q})
+		given += q
+	}
+	parts := dst[start:]
+
+	if rem := lots - given; rem > 0 {
+		switch policy {
+		case RemainderLargest:
+			distribute(parts, rem, func(i int) uint64 {
+				hi, lo := bits.Mul64(lots, weights[i].fp)
+				_, r := bits.Div64(hi, lo, sum)
+				return r
+			})
+		case RemainderFirstN:
+			for i := 0; rem > 0; i++ {
+				if weights[i].fp != 0 {
+					parts[i].fp++
+					rem--
+				}
+			}
+		case RemainderRoundRobin:
+			i := offset % len(parts)
+			if i < 0 {
+				i += len(parts)
+			}
+			for ; rem > 0; i = (i + 1) % len(parts) {
+				if weights[i].fp != 0 {
+					parts[i].fp++
+					rem--
+				}
+			}
+			offset = i
+		case RemainderLargestWeight:
+			distribute(parts, rem, func(i int) uint64 {
+				return weights[i].fp
+			})
+		}
+	}
+
+	for i := range parts {
+		parts[i].fp *= unit
+	}
+	return dst, offset, nil
+}
+
+// distribute adds one to the rem parts with the largest keys, breaking ties by index. rem must be less than
+// the number of non-zero keys. The rem-th largest key is found with a radix select on each byte of the keys,
+// which needs no scratch space.
+func distribute(parts []Decimal, rem uint64, key func(i int) uint64) {
+	// threshold is built up a byte at a time and need is the number of parts still to be chosen among
+	// those with keys that start with the bytes of threshold found so far
+	var threshold uint64
+	need := rem
+	for shift := 56; shift >= 0; shift -= 8 {
+		var counts [256]uint64
+		mask := ^uint64(0) << (shift + 8)
+		for i := range parts {
+			if k := key(i); k&mask == threshold {
+				counts[k>>shift&0xff]++
+			}
+		}
+		for b := 255; b >= 0; b-- {
+			if counts[b] >= need {
+				threshold |= uint64(b) << shift
+				break
+			}
+			need -= counts[b]
+		}
+	}
+
+	// every key larger than threshold is chosen, and the first need keys equal to it
+	for i := range parts {
+		if k := key(i); k > threshold {
+			parts[i].fp++
+		} else if k == threshold && need > 0 {
+			parts[i].fp++
+			need--
+		}
+	}
+}
+
+// Split splits total into n parts that differ by at most the smallest unit and sum exactly to total. The
+// larger parts come first. It returns ErrDivisionByZero if n is not positive.
+func Split(total Decimal, n int) ([]Decimal, error) {
+	if n <= 0 {
+		return nil, ErrDivisionByZero
+	}
+	return SplitInto(make([]Decimal, 0, n), total, n)
+}
+
+// SplitInto splits total as Split does, appending the parts to dst and returning the extended slice. It
+// does not allocate if dst has enough capacity.
+func SplitInto(dst []Decimal, total Decimal, n int) ([]Decimal, error) {
+	if n <= 0 {
+		return dst, ErrDivisionByZero
+	}
+	q, r := total.fp/uint64(n), total.fp%uint64(n)
+	for i := 0; i < n; i++ {
+		fp := q
+		if uint64(i) < r {
+			fp++
+		}
+		dst = append(dst, Decimal{fp: fp})
+	}
+	return dst, nil
+}
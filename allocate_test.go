@@ -0,0 +1,278 @@
+package udecimal_test
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var remainderPolicies = []RemainderPolicy{RemainderLargest, RemainderFirstN, RemainderRoundRobin, RemainderLargestWeight}
+
+func parseAll(values ...string) []Decimal {
+	fs := make([]Decimal, len(values))
+	for i, s := range values {
+		fs[i] = MustParse(s)
+	}
+	return fs
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		total    string
+		weights  []Decimal
+		lot      string
+		policy   RemainderPolicy
+		expected []Decimal
+	}{
+		{"100", parseAll("1", "1", "1"), "0", RemainderLargest, parseAll("33.33333334", "33.33333333", "33.33333333")},
+		{"100", parseAll("1", "1", "1"), "1", RemainderLargest, parseAll("34", "33", "33")},
+		{"10", parseAll("0.1", "0.2", "0.7"), "1", RemainderLargest, parseAll("1", "2", "7")},
+		{"10", parseAll("3", "3", "4"), "4", RemainderLargest, nil},
+		{"1", parseAll("0.14", "0.36", "0.5"), "0.1", RemainderLargest, parseAll("0.1", "0.4", "0.5")},
+		{"1", parseAll("0.14", "0.36", "0.5"), "0.1", RemainderFirstN, parseAll("0.2", "0.3", "0.5")},
+		{"1", parseAll("0.14", "0.36", "0.5"), "0.1", RemainderRoundRobin, parseAll("0.2", "0.3", "0.5")},
+		{"1", parseAll("0.14", "0.36", "0.5"), "0.1", RemainderLargestWeight, parseAll("0.1", "0.3", "0.6")},
+		{"1", parseAll("0.15", "0.35", "0.5"), "0.1", RemainderLargest, parseAll("0.2", "0.3", "0.5")},
+		{"7", parseAll("1", "2", "2", "2"), "1", RemainderLargest, parseAll("1", "2", "2", "2")},
+		{"8", parseAll("1", "2", "2", "2"), "1", RemainderLargest, parseAll("1", "3", "2", "2")},
+		{"8", parseAll("1", "2", "2", "2"), "1", RemainderFirstN, parseAll("2", "2", "2", "2")},
+		{"8", parseAll("1", "2", "2", "2"), "1", RemainderLargestWeight, parseAll("1", "3", "2", "2")},
+		{"5", parseAll("0", "1", "0", "1", "1"), "1", RemainderFirstN, parseAll("0", "2", "0", "2", "1")},
+		{"5", parseAll("0", "1", "0", "1", "1"), "1", RemainderRoundRobin, parseAll("0", "2", "0", "2", "1")},
+		{"5", parseAll("0", "1", "0", "1", "1"), "1", RemainderLargestWeight, parseAll("0", "2", "0", "2", "1")},
+		{"5", parseAll("0", "1", "0", "1", "1"), "1", RemainderLargest, parseAll("0", "2", "0", "2", "1")},
+		{"0", parseAll("1", "2"), "0.001", RemainderLargest, parseAll("0", "0")},
+		{"99999999999.99999999", parseAll("1", "1"), "0", RemainderLargest, parseAll("50000000000", "49999999999.99999999")},
+		{"99999999999.99999999", parseAll("99999999999.99999999"), "0", RemainderLargest, parseAll("99999999999.99999999")},
+	}
+
+	for _, tt := range tests {
+		parts, err := Allocate(MustParse(tt.total), tt.weights, MustParse(tt.lot), tt.policy)
+		if tt.expected == nil {
+			assert.True(t, errors.Is(err, ErrLotSize), "%s %v", tt.total, tt.weights)
+			continue
+		}
+		if assert.NoError(t, err, "%s %v %v", tt.total, tt.weights, tt.policy) {
+			assert.Equal(t, tt.expected, parts, "%s %v %v", tt.total, tt.weights, tt.policy)
+		}
+	}
+}
+
+func TestAllocateErrors(t *testing.T) {
+	_, err := Allocate(MustParse("1"), nil, Zero, RemainderLargest)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+	_, err = Allocate(MustParse("1"), parseAll("0", "0"), Zero, RemainderLargest)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+	_, err = Allocate(MustParse("1"), []Decimal{Max, SmallestUnit}, Zero, RemainderLargest)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = Allocate(MustParse("1.5"), parseAll("1"), MustParse("1"), RemainderLargest)
+	assert.True(t, errors.Is(err, ErrLotSize))
+	_, err = Allocate(MustParse("1"), parseAll("1"), Zero, RemainderPolicy(4))
+	assert.Error(t, err)
+
+	dst := parseAll("7")
+	dst2, err := AllocateInto(dst, MustParse("1.5"), parseAll("1"), MustParse("1"), RemainderLargest)
+	assert.Error(t, err)
+	assert.Equal(t, dst, dst2)
+}
+
+// referenceAllocate allocates with exact big.Int shares, sorting the parts to distribute the remainder. Round
+// robin lots are dealt from the part at start.
+func referenceAllocate(total Decimal, weights []Decimal, lot Decimal, policy RemainderPolicy, start int) []Decimal {
+	unit := lot.Units()
+	if unit == 0 {
+		unit = 1
+	}
+	lots := new(big.Int).SetUint64(total.Units() / unit)
+	sum := new(big.Int)
+	for _, w := range weights {
+		sum.Add(sum, w.BigInt())
+	}
+
+	parts := make([]uint64, len(weights))
+	remainders := make([]uint64, len(weights))
+	var given uint64
+	for i, w := range weights {
+		q, r := new(big.Int).QuoRem(new(big.Int).Mul(lots, w.BigInt()), sum, new(big.Int))
+		parts[i] = q.Uint64()
+		remainders[i] = r.Uint64()
+		given += parts[i]
+	}
+	rem := lots.Uint64() - given
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	switch policy {
+	case RemainderRoundRobin:
+		order = append(order[start%len(order):], order[:start%len(order)]...)
+	case RemainderLargest:
+		sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	case RemainderLargestWeight:
+		sort.SliceStable(order, func(a, b int) bool { return weights[order[a]].GreaterThan(weights[order[b]]) })
+	}
+	for _, i := range order {
+		if rem == 0 {
+			break
+		}
+		if !weights[i].IsZero() {
+			parts[i]++
+			rem--
+		}
+	}
+
+	result := make([]Decimal, len(parts))
+	for i, p := range parts {
+		result[i] = FromUnits(p * unit)
+	}
+	return result
+}
+
+func TestAllocateRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+	lots := parseAll("0", "0.00000001", "0.00000025", "0.001", "0.05", "1", "100")
+
+	for i := 0; i < 5000; i++ {
+		lot := lots[rnd.Intn(len(lots))]
+		unit := lot.Units()
+		if unit == 0 {
+			unit = 1
+		}
+		total := FromUnits(rnd.Uint64() % (Max.Units()/unit + 1) / pow10Units[rnd.Intn(len(pow10Units))] * unit)
+
+		n := rnd.Intn(20) + 1
+		if i%50 == 0 {
+			n = 500
+		}
+		weights := make([]Decimal, n)
+		for j := range weights {
+			switch rnd.Intn(4) {
+			case 0:
+				weights[j] = Zero
+			case 1:
+				weights[j] = MustParse("1")
+			default:
+				weights[j] = FromUnits(rnd.Uint64() % 100000000000 / pow10Units[rnd.Intn(len(pow10Units))])
+			}
+		}
+		weights[rnd.Intn(len(weights))] = MustParse("0.5")
+
+		for _, policy := range remainderPolicies {
+			parts, err := Allocate(total, weights, lot, policy)
+			if !assert.NoError(t, err) {
+				continue
+			}
+			if !assert.Equal(t, referenceAllocate(total, weights, lot, policy, 0), parts, "%s %v %s %v", total, weights, lot, policy) {
+				return
+			}
+
+			var sum Decimal
+			for _, p := range parts {
+				assert.True(t, p.IsMultipleOf(FromUnits(unit)))
+				sum = sum.Add(p)
+			}
+			assert.Equal(t, total, sum)
+		}
+
+		start := rnd.Intn(2 * n)
+		parts, _, err := AllocateRoundRobin(total, weights, lot, start)
+		if assert.NoError(t, err) {
+			assert.Equal(t, referenceAllocate(total, weights, lot, RemainderRoundRobin, start), parts, "%s %v %s %d", total, weights, lot, start)
+		}
+	}
+}
+
+func TestAllocateRoundRobin(t *testing.T) {
+	// each allocation deals the left over lot to the next part
+	weights := parseAll("1", "1", "1")
+	start := 0
+	for _, expected := range [][]Decimal{parseAll("2", "1", "1"), parseAll("1", "2", "1"), parseAll("1", "1", "2"), parseAll("2", "1", "1")} {
+		var parts []Decimal
+		var err error
+		parts, start, err = AllocateRoundRobin(MustParse("4"), weights, MustParse("1"), start)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, parts)
+	}
+
+	tests := []struct {
+		total    string
+		weights  []Decimal
+		start    int
+		expected []Decimal
+		next     int
+	}{
+		{"5", parseAll("1", "0", "1", "1"), 1, parseAll("1", "0", "2", "2"), 0},
+		{"5", parseAll("1", "0", "1", "1"), 3, parseAll("2", "0", "1", "2"), 1},
+		{"4", parseAll("1", "1", "1"), 7, parseAll("1", "2", "1"), 2},
+		{"4", parseAll("1", "1", "1"), -1, parseAll("1", "1", "2"), 0},
+		{"3", parseAll("1", "1", "1"), 2, parseAll("1", "1", "1"), 2},
+	}
+	for _, tt := range tests {
+		parts, next, err := AllocateRoundRobin(MustParse(tt.total), tt.weights, MustParse("1"), tt.start)
+		if assert.NoError(t, err, "%s %v %d", tt.total, tt.weights, tt.start) {
+			assert.Equal(t, tt.expected, parts, "%s %v %d", tt.total, tt.weights, tt.start)
+			assert.Equal(t, tt.next, next, "%s %v %d", tt.total, tt.weights, tt.start)
+		}
+	}
+
+	_, next, err := AllocateRoundRobin(MustParse("1.5"), weights, MustParse("1"), 2)
+	assert.True(t, errors.Is(err, ErrLotSize))
+	assert.Equal(t, 2, next)
+}
+
+func TestSplit(t *testing.T) {
+	parts, err := Split(MustParse("10"), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, parseAll("3.33333334", "3.33333333", "3.33333333"), parts)
+
+	parts, err = Split(MustParse("0.00000002"), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, parseAll("0.00000001", "0.00000001", "0"), parts)
+
+	parts, err = Split(Max, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []Decimal{Max}, parts)
+
+	parts, err = Split(Zero, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []Decimal{Zero, Zero}, parts)
+
+	_, err = Split(MustParse("1"), 0)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+	_, err = Split(MustParse("1"), -1)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+
+	parts, err = SplitInto(parseAll("1"), MustParse("1"), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, parseAll("1", "0.5", "0.5"), parts)
+}
+
+func TestRemainderPolicyString(t *testing.T) {
+	assert.Equal(t, "Largest", RemainderLargest.String())
+	assert.Equal(t, "FirstN", RemainderFirstN.String())
+	assert.Equal(t, "RoundRobin", RemainderRoundRobin.String())
+	assert.Equal(t, "LargestWeight", RemainderLargestWeight.String())
+	assert.Equal(t, "RemainderPolicy(7)", RemainderPolicy(7).String())
+}
+
+func TestAllocateAllocs(t *testing.T) {
+	total := MustParse("1000.5")
+	weights := parseAll("3", "1.5", "0.25", "7", "0", "2")
+	lot := MustParse("0.1")
+	dst := make([]Decimal, 0, len(weights))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, policy := range remainderPolicies {
+			dst, _ = AllocateInto(dst[:0], total, weights, lot, policy)
+		}
+		dst, _, _ = AllocateRoundRobinInto(dst[:0], total, weights, lot, 3)
+		dst, _ = SplitInto(dst[:0], total, 6)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
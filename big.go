@@ -0,0 +1,216 @@
+package udecimal
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+var (
+	bigScale        = new(big.Int).SetUint64(scale)
+	bigFloatScale2x = new(big.Float).SetUint64(2 * scale)
+)
+
+// bigBuffer holds pooled scratch values so that the conversions to and from big.Float do not allocate
+type bigBuffer struct {
+	q, r big.Int
+	f    big.Float
+}
+
+var bigBuffers = sync.Pool{New: func() interface{} { return new(bigBuffer) }}
+
+// BigInt returns the fixed point value of f, the number of 0.00000001 units, as a big.Int
+func (f Decimal) BigInt() *big.Int {
+	return f.BigIntInto(new(big.Int))
+}
+
+// BigIntInto sets z to the fixed point value of f, as returned by BigInt, and returns z
+func (f Decimal) BigIntInto(z *big.Int) *big.Int {
+	return z.SetUint64(f.fp)
+}
+
+// Rat returns the exact value of f as a big.Rat
+func (f Decimal) Rat() *big.Rat {
+	return f.RatInto(new(big.Rat))
+}
+
+// RatInto sets z to the exact value of f and returns z. It does not allocate if z has been used before.
+func (f Decimal) RatInto(z *big.Rat) *big.Rat {
+	// the fraction is reduced here rather than by Quo, which allocates
+	a, b := f.fp, uint64(scale)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	z.SetUint64(f.fp / a)
+	// SetUint64 sets the denominator to 1, so Denom returns a reference to it
+	z.Denom().SetUint64(scale / a)
+	return z
+}
+
+// BigFloat returns f as a big.Float with prec bits of precision, rounded to nearest even. A prec of 0 is
+// treated as 64.
+func (f Decimal) BigFloat(prec uint) *big.Float {
+	if prec == 0 {
+		prec = 64
+	}
+	return f.BigFloatInto(new(big.Float).SetPrec(prec))
+}
+
+// BigFloatInto sets z to f rounded to the precision and rounding mode of z and returns z. If z has a
+// precision of 0 it is set to 64. It does not allocate if z has been used with the same precision before.
+func (f Decimal) BigFloatInto(z *big.Float) *big.Float {
+	if z.Prec() == 0 {
+		z.SetPrec(64)
+	}
+	if f.fp == 0 {
+		return z.SetUint64(0)
+	}
+
+	// big.Float.Quo allocates, so the quotient is computed as an integer with at least 2 bits more than
+	// the precision of z. The lowest bit is set if the division is inexact, which keeps values below, at
+	// and above the half way point apart, so that SetInt rounds once as Quo would.
+	shift := z.Prec() + 2 + uint(bits.Len64(scale))
+	buf := bigBuffers.Get().(*bigBuffer)
+	q, r := &buf.q, &buf.r
+	q.Lsh(q.SetUint64(f.fp), shift)
+	q.QuoRem(q, bigScale, r)
+	if r.Sign() != 0 {
+		q.SetBit(q, 0, 1)
+	}
+	z.SetMantExp(z.SetInt(q), -int(shift))
+	bigBuffers.Put(buf)
+	return z
+}
+
+// FromBigInt creates a Decimal from a fixed point value as returned by BigInt. It returns ErrNegative if x
+// is negative and ErrOverflow if it is larger than the fixed point value of MAX.
+func FromBigInt(x *big.Int) (Decimal, error) {
+	var f Decimal
+	err := f.SetFromBigInt(x)
+	return f, err
+}
+
+// SetFromBigInt sets f as FromBigInt does. f is unchanged on error.
+func (f *Decimal) SetFromBigInt(x *big.Int) error {
+	if x.Sign() < 0 {
+		return ErrNegative
+	}
+	if !x.IsUint64() || x.Uint64() > maxFP {
+		return ErrOverflow
+	}
+	f.fp = x.Uint64()
+	return nil
+}
+
+// FromRat creates a Decimal from x, rounding to 8 decimal places according to mode. It returns
+// ErrNegative if x is negative and ErrOverflow if the result is larger than MAX.
+func FromRat(x *big.Rat, mode RoundMode) (Decimal, error) {
+	var f Decimal
+	err := f.SetFromRat(x, mode)
+	return f, err
+}
+
+// SetFromRat sets f as FromRat does. f is unchanged on error. It does not allocate if the numerator and
+// denominator of x fit in a uint64.
+func (f *Decimal) SetFromRat(x *big.Rat, mode RoundMode) error {
+	if x.Sign() < 0 {
+		return ErrNegative
+	}
+	num, den := x.Num(), x.Denom()
+	if num.IsUint64() && den.IsUint64() {
+		hi, lo := bits.Mul64(num.Uint64(), scale)
+		d := den.Uint64()
+		if hi >= d {
+			return ErrOverflow
+		}
+		fp, err := quoUnits(hi, lo, d, mode)
+		if err != nil {
+			return err
+		}
+		f.fp = fp
+		return nil
+	}
+
+	q, r := new(big.Int).QuoRem(new(big.Int).Mul(num, bigScale), den, new(big.Int))
+	if !q.IsUint64() || q.Uint64() > maxFP {
+		return ErrOverflow
+	}
+	fp := q.Uint64()
+	if roundUpBig(fp, r, den, mode) {
+		fp++
+		if fp > maxFP {
+			return ErrOverflow
+		}
+	}
+	f.fp = fp
+	return nil
+}
+
+// roundUpBig is roundUp for a remainder r and divisor d that may not fit in a uint64. r is overwritten.
+func roundUpBig(q uint64, r, d *big.Int, mode RoundMode) bool {
+	if r.Sign() == 0 {
+		return false
+	}
+
+	half := r.Lsh(r, 1).Cmp(d)
+	switch mode {
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfDown:
+		return half > 0
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && q&1 == 1)
+	case RoundUp, RoundCeiling:
+		return true
+	}
+	return false
+}
+
+// FromBigFloat creates a Decimal from x, rounding to 8 decimal places according to mode. It returns
+// ErrNegative if x is negative and ErrOverflow if x is infinite or the result is larger than MAX.
+func FromBigFloat(x *big.Float, mode RoundMode) (Decimal, error) {
+	var f Decimal
+	err := f.SetFromBigFloat(x, mode)
+	return f, err
+}
+
+// SetFromBigFloat sets f as FromBigFloat does. f is unchanged on error. It does not allocate once the pooled
+// scratch space has grown to fit x.
+func (f *Decimal) SetFromBigFloat(x *big.Float, mode RoundMode) error {
+	if x.Sign() < 0 {
+		return ErrNegative
+	}
+	if x.IsInf() {
+		return ErrOverflow
+	}
+	if x.MantExp(nil) > 64 {
+		// at least 2^64, which is more than MAX
+		return ErrOverflow
+	}
+
+	// x * 2 * scale is exact with the 28 bits of 2 * scale more than x. Its integer part is the truncated result followed by
+	// the bit worth half a unit, and it is inexact if anything is left below that bit. Together they give
+	// the remainder to round with in quarters of a unit.
+	buf := bigBuffers.Get().(*bigBuffer)
+	defer bigBuffers.Put(buf)
+	t, q := &buf.f, &buf.q
+	t.SetPrec(x.MinPrec()+uint(bits.Len64(2*scale))).Mul(x, bigFloatScale2x)
+	_, acc := t.Int(q)
+	r := 2 * uint64(q.Bit(0))
+	if acc != big.Exact {
+		r++
+	}
+	q.Rsh(q, 1)
+	if !q.IsUint64() || q.Uint64() > maxFP {
+		return ErrOverflow
+	}
+	fp := q.Uint64()
+	if roundUp(fp, r, 4, mode) {
+		fp++
+		if fp > maxFP {
+			return ErrOverflow
+		}
+	}
+	f.fp = fp
+	return nil
+}
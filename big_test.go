@@ -0,0 +1,202 @@
+package udecimal_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigInt(t *testing.T) {
+	assert.Equal(t, "150000000", MustParse("1.5").BigInt().String())
+	assert.Equal(t, "9999999999999999999", Max.BigInt().String())
+
+	z := big.NewInt(-5)
+	assert.Same(t, z, SmallestUnit.BigIntInto(z))
+	assert.Equal(t, int64(1), z.Int64())
+
+	f, err := FromBigInt(big.NewInt(150000000))
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("1.5"), f)
+
+	f, err = FromBigInt(Max.BigInt())
+	assert.NoError(t, err)
+	assert.Equal(t, Max, f)
+
+	_, err = FromBigInt(big.NewInt(-1))
+	assert.True(t, errors.Is(err, ErrNegative))
+	_, err = FromBigInt(new(big.Int).Add(Max.BigInt(), big.NewInt(1)))
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	f = MustParse("2")
+	assert.Error(t, f.SetFromBigInt(new(big.Int).Lsh(big.NewInt(1), 100)))
+	assert.Equal(t, MustParse("2"), f)
+	assert.NoError(t, f.SetFromBigInt(big.NewInt(1)))
+	assert.Equal(t, SmallestUnit, f)
+}
+
+func TestRat(t *testing.T) {
+	assert.Equal(t, "3/2", MustParse("1.5").Rat().String())
+	assert.Equal(t, "1/100000000", SmallestUnit.Rat().String())
+	assert.Equal(t, "0/1", Zero.Rat().String())
+	assert.Equal(t, "9999999999999999999/100000000", Max.Rat().String())
+
+	tests := []struct {
+		rat      string
+		mode     RoundMode
+		expected string
+	}{
+		{"1/3", RoundHalfUp, "0.33333333"},
+		{"2/3", RoundHalfUp, "0.66666667"},
+		{"2/3", RoundDown, "0.66666666"},
+		{"1/200000000", RoundHalfUp, "0.00000001"},
+		{"1/200000000", RoundHalfDown, "0"},
+		{"1/200000000", RoundHalfEven, "0"},
+		{"3/200000000", RoundHalfEven, "0.00000002"},
+		{"1/300000000", RoundUp, "0.00000001"},
+		{"1/300000000", RoundCeiling, "0.00000001"},
+		{"1/300000000", RoundFloor, "0"},
+		{"123456789", RoundDown, "123456789"},
+		{"99999999999999999999/1000000000", RoundDown, "99999999999.99999999"},
+		{"1/340282366920938463463374607431768211456", RoundUp, "0.00000001"},
+		{"1/340282366920938463463374607431768211456", RoundHalfUp, "0"},
+		{"340282366920938463463374607431768211457/3402823669209384634633746074317682114560", RoundHalfUp, "0.1"},
+	}
+
+	for _, tt := range tests {
+		r, ok := new(big.Rat).SetString(tt.rat)
+		assert.True(t, ok)
+		f, err := FromRat(r, tt.mode)
+		if assert.NoError(t, err, tt.rat) {
+			assert.Equal(t, tt.expected, f.String(), "%s %v", tt.rat, tt.mode)
+		}
+	}
+
+	_, err := FromRat(big.NewRat(-1, 3), RoundDown)
+	assert.True(t, errors.Is(err, ErrNegative))
+	_, err = FromRat(big.NewRat(100000000000, 1), RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = FromRat(new(big.Rat).SetFrac(new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(3)), RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	r, _ := new(big.Rat).SetString("99999999999999999995/1000000000")
+	_, err = FromRat(r, RoundHalfUp)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	f, err := FromRat(r, RoundHalfDown)
+	assert.NoError(t, err)
+	assert.Equal(t, Max, f)
+}
+
+func TestBigFloat(t *testing.T) {
+	assert.Equal(t, "1.5", MustParse("1.5").BigFloat(0).Text('g', -1))
+	assert.Equal(t, uint(100), MustParse("1.5").BigFloat(100).Prec())
+	assert.Equal(t, 0.1, func() float64 { v, _ := MustParse("0.1").BigFloat(53).Float64(); return v }())
+
+	z := new(big.Float).SetPrec(24)
+	assert.Same(t, z, MustParse("0.1").BigFloatInto(z))
+	v, _ := z.Float32()
+	assert.Equal(t, float32(0.1), v)
+
+	f, err := FromBigFloat(big.NewFloat(0.5), RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("0.5"), f)
+
+	f, err = FromBigFloat(big.NewFloat(0.1), RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("0.1"), f)
+
+	f, err = FromBigFloat(big.NewFloat(0.1), RoundUp)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("0.10000001"), f)
+
+	_, err = FromBigFloat(big.NewFloat(-0.5), RoundDown)
+	assert.True(t, errors.Is(err, ErrNegative))
+	_, err = FromBigFloat(big.NewFloat(math.Inf(1)), RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = FromBigFloat(new(big.Float).SetMantExp(big.NewFloat(1), 10000), RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = FromBigFloat(big.NewFloat(1e11), RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	f, err = FromBigFloat(new(big.Float).SetMantExp(big.NewFloat(1), -10000), RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, Zero, f)
+}
+
+func TestBigRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(29))
+	for i := 0; i < 10000; i++ {
+		f := MustParse(randomUnits(rnd).String())
+
+		f0, err := FromBigInt(f.BigInt())
+		assert.NoError(t, err)
+		assert.Equal(t, f, f0)
+
+		for _, mode := range roundModes {
+			f0, err = FromRat(f.Rat(), mode)
+			assert.NoError(t, err)
+			assert.Equal(t, f, f0)
+		}
+
+		// 96 bits of mantissa are enough to round back to the same value
+		f0, err = FromBigFloat(f.BigFloat(96), RoundHalfEven)
+		assert.NoError(t, err)
+		assert.Equal(t, f, f0)
+
+		expected, _ := new(big.Float).SetPrec(53).SetRat(f.Rat()).Float64()
+		actual, _ := f.BigFloat(53).Float64()
+		assert.Equal(t, expected, actual)
+	}
+}
+
+// TestBigFloatRat compares the big.Float conversions with exact conversions through big.Rat
+func TestBigFloatRat(t *testing.T) {
+	rnd := rand.New(rand.NewSource(31))
+	modes := []big.RoundingMode{big.ToNearestEven, big.ToNearestAway, big.ToZero, big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf}
+	for i := 0; i < 10000; i++ {
+		f := MustParse(randomUnits(rnd).String())
+		prec := uint(rnd.Intn(120) + 1)
+		mode := modes[rnd.Intn(len(modes))]
+
+		expected := new(big.Float).SetPrec(prec).SetMode(mode).SetRat(f.Rat())
+		actual := f.BigFloatInto(new(big.Float).SetPrec(prec).SetMode(mode))
+		assert.Equal(t, 0, expected.Cmp(actual), "%s %d %v", f, prec, mode)
+
+		// a float with up to 12 fractional digits, close to a rounding boundary half of the time
+		x := new(big.Float).SetPrec(prec).SetMode(mode).SetRat(big.NewRat(int64(rnd.Uint64()>>24), 1000000000000))
+		if i%2 == 0 {
+			x.SetPrec(prec).SetRat(new(big.Rat).Add(f.Rat(), big.NewRat(int64(rnd.Intn(3)), 200000000)))
+		}
+		for _, rm := range roundModes {
+			r, _ := x.Rat(nil)
+			expectedF, expectedErr := FromRat(r, rm)
+			actualF, err := FromBigFloat(x, rm)
+			assert.Equal(t, expectedErr, err, "%s %v", x.Text('g', -1), rm)
+			assert.Equal(t, expectedF, actualF, "%s %v", x.Text('g', -1), rm)
+		}
+	}
+}
+
+func TestBigAllocs(t *testing.T) {
+	f := MustParse("1234.5678")
+	z := new(big.Int)
+	r := big.NewRat(1, 3)
+	zr := new(big.Rat)
+	zf := new(big.Float).SetPrec(53)
+	x := big.NewFloat(1234.56789)
+	f.RatInto(zr)
+	f.BigFloatInto(zf)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		f.BigIntInto(z)
+		_ = f.SetFromBigInt(z)
+		_ = f.SetFromRat(r, RoundHalfEven)
+		f.RatInto(zr)
+		f.BigFloatInto(zf)
+		_ = f.SetFromBigFloat(x, RoundHalfEven)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,102 @@
+package udecimal
+
+import "math/bits"
+
+// addUnits adds two fixed point values, failing if the sum is larger than maxFP
+func addUnits(a, b uint64) (uint64, error) {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 || sum > maxFP {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+// subUnits subtracts b from a, failing if the difference is negative
+func subUnits(a, b uint64) (uint64, error) {
+	if a < b {
+		return 0, ErrNegative
+	}
+	return a - b, nil
+}
+
+// mulUnits multiplies two fixed point values with the given unit (10^places), rounding the exact
+// 128 bit product according to mode
+func mulUnits(a, b, unit uint64, mode RoundMode) (uint64, error) {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= unit {
+		return 0, ErrOverflow
+	}
+	return quoUnits(hi, lo, unit, mode)
+}
+
+// divUnits divides two fixed point values with the given unit (10^places), rounding the exact
+// quotient according to mode
+func divUnits(a, b, unit uint64, mode RoundMode) (uint64, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	hi, lo := bits.Mul64(a, unit)
+	if hi >= b {
+		return 0, ErrOverflow
+	}
+	return quoUnits(hi, lo, b, mode)
+}
+
+// quoUnits divides the 128 bit value hi:lo by d, which must be larger than hi, rounding the quotient
+// according to mode
+func quoUnits(hi, lo, d uint64, mode RoundMode) (uint64, error) {
+	q, r := bits.Div64(hi, lo, d)
+	if q > maxFP {
+		return 0, ErrOverflow
+	}
+	if roundUp(q, r, d, mode) {
+		q++
+		if q > maxFP {
+			return 0, ErrOverflow
+		}
+	}
+	return q, nil
+}
+
+// AddErr adds f0 to f, returning ErrOverflow if the result is larger than MAX. This is stricter than Add,
+// which only panics if the sum does not fit in 64 bits and so can return values above MAX.
+func (f Decimal) AddErr(f0 Decimal) (Decimal, error) {
+	fp, err := addUnits(f.fp, f0.fp)
+	return Decimal{fp: fp}, err
+}
+
+// SubErr subtracts f0 from f, returning ErrNegative instead of panicking if f0 is larger than f
+func (f Decimal) SubErr(f0 Decimal) (Decimal, error) {
+	fp, err := subUnits(f.fp, f0.fp)
+	return Decimal{fp: fp}, err
+}
+
+// MulErr multiplies f by f0, truncating at the 8th decimal place like Mul. It returns ErrOverflow
+// instead of panicking if the result is larger than MAX.
+func (f Decimal) MulErr(f0 Decimal) (Decimal, error) {
+	fp, err := mulUnits(f.fp, f0.fp, scale, RoundDown)
+	return Decimal{fp: fp}, err
+}
+
+// DivErr divides f by f0, rounding half-up at the 8th decimal place like Div. It returns
+// ErrDivisionByZero or ErrOverflow instead of panicking.
+func (f Decimal) DivErr(f0 Decimal) (Decimal, error) {
+	fp, err := divUnits(f.fp, f0.fp, scale, RoundHalfUp)
+	return Decimal{fp: fp}, err
+}
+
+// RoundErr rounds f (half-up) to n decimal places like Round, returning ErrOverflow instead of
+// panicking if the rounded value is larger than MAX
+func (f Decimal) RoundErr(n int) (Decimal, error) {
+	return f.RoundWithErr(n, RoundHalfUp)
+}
+
+// RoundWithErr rounds f to n decimal places using mode like RoundWith, returning ErrOverflow instead
+// of panicking if the rounded value is larger than MAX
+func (f Decimal) RoundWithErr(n int, mode RoundMode) (Decimal, error) {
+	fp, ok := roundUnits(f.fp, nPlaces, n, mode)
+	if !ok {
+		return Zero, ErrOverflow
+	}
+	return Decimal{fp: fp}, nil
+}
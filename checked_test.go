@@ -0,0 +1,117 @@
+package udecimal_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddErr(t *testing.T) {
+	f, err := MustParse("1.5").AddErr(MustParse("2.25"))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.75", f.String())
+
+	f, err = MustParse("99999999999.99999998").AddErr(MustParse("0.00000001"))
+	assert.NoError(t, err)
+	assert.Equal(t, "99999999999.99999999", f.String())
+
+	_, err = MustParse("99999999999.99999999").AddErr(MustParse("0.00000001"))
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = MustParse("99999999999").AddErr(MustParse("99999999999"))
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	// Add only panics when the sum does not fit in 64 bits, so it returns sums that AddErr rejects
+	f = MustParse("90000000000").Add(MustParse("90000000000"))
+	assert.Equal(t, "180000000000", f.String())
+	_, err = MustParse("90000000000").AddErr(MustParse("90000000000"))
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestSubErr(t *testing.T) {
+	f, err := MustParse("1").SubErr(MustParse("0.3333333"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.6666667", f.String())
+
+	f, err = MustParse("1").SubErr(MustParse("1"))
+	assert.NoError(t, err)
+	assert.True(t, f.IsZero())
+
+	_, err = MustParse(".001").SubErr(MustParse(".002"))
+	assert.True(t, errors.Is(err, ErrNegative))
+}
+
+func TestMulErr(t *testing.T) {
+	f, err := MustParse("123.456").MulErr(MustParse("1000"))
+	assert.NoError(t, err)
+	assert.Equal(t, "123456", f.String())
+
+	f, err = MustParse("0.00000001").MulErr(MustParse("0.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0", f.String())
+
+	f, err = MustParse("33333333333.33333333").MulErr(MustParse("3"))
+	assert.NoError(t, err)
+	assert.Equal(t, "99999999999.99999999", f.String())
+
+	_, err = MustParse("50000000000").MulErr(MustParse("2"))
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = MustParse("99999999999").MulErr(MustParse("99999999999"))
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	for _, s := range []string{"0", "0.1", "1.23456789", "10000.1", "123456789"} {
+		for _, s0 := range []string{"0", "0.0001", "0.5", "3", "12.5678"} {
+			f, err := MustParse(s).MulErr(MustParse(s0))
+			assert.NoError(t, err)
+			assert.Equal(t, MustParse(s).Mul(MustParse(s0)), f, "%s * %s", s, s0)
+		}
+	}
+}
+
+func TestDivErr(t *testing.T) {
+	f, err := MustParse("2").DivErr(MustParse("3"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.66666667", f.String())
+
+	_, err = MustParse("2").DivErr(Zero)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+
+	_, err = MustParse("1000000000").DivErr(MustParse("0.001"))
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = MustParse("99999999999.99999999").DivErr(MustParse("0.99999999"))
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestRoundErr(t *testing.T) {
+	f, err := MustParse("1.12345").RoundErr(4)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1235", f.String())
+
+	f, err = MustParse("1.12345").RoundWithErr(4, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1234", f.String())
+
+	_, err = MustParse("99999999999.5").RoundErr(0)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = MustParse("99999999999.00000001").RoundWithErr(0, RoundCeiling)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestCheckedAllocs(t *testing.T) {
+	f0 := MustParse("1234.5678")
+	f1 := MustParse("0.0001")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = f0.AddErr(f1)
+		_, _ = f1.SubErr(f0)
+		_, _ = f0.MulErr(f1)
+		_, _ = f0.DivErr(Zero)
+		_, _ = f0.RoundErr(2)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
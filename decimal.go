@@ -9,7 +9,6 @@ import (
 	"io"
 	"math"
 	"strconv"
-	"strings"
 )
 
 // Decimal is a decimal precision 38.24 number (supports 11.7 digits).
@@ -17,53 +16,53 @@ type Decimal struct {
 	fp uint64
 }
 
-// the following constants can be changed to configure a different number of decimal places - these are
-// the only required changes.
+// the following constants configure the number of decimal places of Decimal. Decimal2, Decimal4 and
+// Decimal6 provide other numbers of decimal places in the same binary.
 const nPlaces = 8
 const scale = uint64(10 * 10 * 10 * 10 * 10 * 10 * 10 * 10)
 const zeros = "00000000"
 const MAX = float64(99999999999.99999999)
 
+// maxFP is the largest permitted fixed point value, i.e. MAX expressed in units of 10^-nPlaces
+const maxFP = uint64(99999999999)*scale + (scale - 1)
+
 var Zero = Decimal{fp: 0}
 
+// Max is the largest representable Decimal, MAX
+var Max = Decimal{fp: maxFP}
+
+// SmallestUnit is the smallest positive Decimal, 10^-8
+var SmallestUnit = Decimal{fp: 1}
+
 var errTooLarge = errors.New("significand too large")
 var errFormat = errors.New("invalid encoding")
 
+// ErrOverflow is returned by the checked arithmetic methods when a result is larger than MAX
+var ErrOverflow = errors.New("decimal overflow")
+
+// ErrNegative is returned by the checked arithmetic methods when a result would be negative
+var ErrNegative = errors.New("decimal negative result")
+
+// ErrDivisionByZero is returned by the checked arithmetic methods when dividing by zero
+var ErrDivisionByZero = errors.New("decimal division by zero")
+
+// Parse creates a Decimal from a string, truncating fractional digits beyond the 8th decimal place.
+// Exponents such as "1.5e3" are applied exactly.
 func Parse(s string) (Decimal, error) {
-	if strings.ContainsAny(s, "eE") {
-		f, err := strconv.ParseFloat(s, 64)
-		if err != nil {
-			return Zero, err
-		}
-		return ParseFloat(f)
-	}
-	period := strings.Index(s, ".")
-	var i uint64
-	var f uint64
-	var err error
-	if period == -1 {
-		i, err = strconv.ParseUint(s, 10, 64)
-		if err != nil {
-			return Zero, errors.New("cannot parse")
-		}
-	} else {
-		if len(s[:period]) > 0 {
-			i, err = strconv.ParseUint(s[:period], 10, 64)
-			if err != nil {
-				return Zero, errors.New("cannot parse")
-			}
-		}
-		fs := s[period+1:]
-		fs = fs + zeros[:max(0, nPlaces-len(fs))]
-		f, err = strconv.ParseUint(fs[0:nPlaces], 10, 64)
-		if err != nil {
-			return Zero, errors.New("cannot parse")
-		}
+	fp, err := parseUnits(s, nPlaces)
+	if err != nil {
+		return Zero, err
 	}
-	if float64(i) > MAX {
-		return Zero, errTooLarge
+	return Decimal{fp: fp}, nil
+}
+
+// ParseBytes creates a Decimal from a byte slice like Parse, without allocating
+func ParseBytes(b []byte) (Decimal, error) {
+	fp, err := parseUnits(bytesToString(b), nPlaces)
+	if err != nil {
+		return Zero, err
 	}
-	return Decimal{fp: (i*scale + f)}, nil
+	return Decimal{fp: fp}, nil
 }
 
 // MustParse creates a new Fixed from a string, and panics if the string could not be parsed
@@ -75,27 +74,27 @@ func MustParse(s string) Decimal {
 	return f
 }
 
-func max(a, b int) int {
-	if a > b {
-		return a
+// ParseFloat creates a Decimal from an float64, rounding at the 8th decimal place
+func ParseFloat(f float64) (Decimal, error) {
+	fp, err := floatUnits(f, nPlaces)
+	if err != nil {
+		return Zero, err
 	}
-	return b
+	return Decimal{fp: fp}, nil
 }
 
-// ParseFloat creates a Decimal from an float64, rounding at the 8th decimal place
-func ParseFloat(f float64) (Decimal, error) {
+// floatUnits converts f into a fixed point value with the given number of decimal places, rounding
+// at the last place
+func floatUnits(f float64, places int) (uint64, error) {
 	if math.IsNaN(f) {
-		return Zero, errors.New("invalid input")
-	}
-	if f >= MAX || f < 0 {
-		return Zero, errors.New("invalid input")
+		return 0, errors.New("invalid input")
 	}
-	round := .5
-	if f < 0 {
-		round = -0.5
+	unit := float64(pow10[places])
+	if f >= float64(maxFP)/unit || f < 0 {
+		return 0, errors.New("invalid input")
 	}
 
-	return Decimal{fp: uint64(f*float64(scale) + round)}, nil
+	return uint64(f*unit + .5), nil
 }
 
 // MustParseFloat creates a new Fixed from a string, and panics if the string could not be parsed
@@ -107,27 +106,61 @@ func MustParseFloat(f float64) Decimal {
 	return r
 }
 
-// New returns a new fixed-point decimal, value * 10 ^ exp.
+// New returns a new fixed-point decimal, value * 10 ^ exp. Digits beyond 8 decimal places are truncated.
+// New panics if the value is larger than MAX.
 func New(value uint64, exp int32) Decimal {
-	if exp >= 0 {
-		mul := uint64(math.Pow10(int(exp)))
-		return NewI(value, 0).Mul(NewI(mul, 0))
+	if exp < 0 {
+		return NewI(value, uint(-int64(exp)))
 	}
-
-	return NewI(value, uint(exp*-1))
+	if value == 0 {
+		return Zero
+	}
+	if int(exp) >= len(pow10) || value > maxFP/pow10[exp] {
+		panic("decimal overflow")
+	}
+	return NewI(value*pow10[exp], 0)
 }
 
 // NewI creates a Decimal for an integer, moving the decimal point n places to the left
-// For example, NewI(123,1) becomes 12.3. If n > 7, the value is truncated
+// For example, NewI(123,1) becomes 12.3. If n > 8, the value is truncated. NewI panics if the value is
+// larger than MAX.
 func NewI(i uint64, n uint) Decimal {
 	if n > nPlaces {
-		i = i / uint64(math.Pow10(int(n-nPlaces)))
-		n = nPlaces
+		if n-nPlaces >= uint(len(pow10)) {
+			return Zero
+		}
+		return Decimal{fp: i / pow10[n-nPlaces]}
 	}
 
-	i = i * uint64(math.Pow10(int(nPlaces-n)))
+	unit := pow10[nPlaces-n]
+	if i > maxFP/unit {
+		panic("decimal overflow")
+	}
+	return Decimal{fp: i * unit}
+}
 
-	return Decimal{fp: i}
+// FromUnits creates a Decimal from its fixed point value, the number of 0.00000001 units. FromUnits panics
+// if units is larger than the units of MAX.
+func FromUnits(units uint64) Decimal {
+	if units > maxFP {
+		panic("decimal overflow")
+	}
+	return Decimal{fp: units}
+}
+
+// Units returns the fixed point value of f, the number of 0.00000001 units
+func (f Decimal) Units() uint64 {
+	return f.fp
+}
+
+// FromIntFrac creates a Decimal from an integer part and a fractional part in 0.00000001 units, so that
+// FromIntFrac(12, 50000000) is 12.5. It returns ErrOverflow if intPart is larger than 99999999999 or
+// fracUnits is not less than 100000000.
+func FromIntFrac(intPart, fracUnits uint64) (Decimal, error) {
+	if intPart > maxFP/scale || fracUnits >= scale {
+		return Zero, ErrOverflow
+	}
+	return Decimal{fp: intPart*scale + fracUnits}, nil
 }
 
 func (f Decimal) IsZero() bool {
@@ -157,57 +190,39 @@ func (f Decimal) Sub(f0 Decimal) Decimal {
 	return Decimal{fp: f.fp - f0.fp}
 }
 
-// Mul multiplies f by f0 returning a Decimal.
+// Mul multiplies f by f0 returning a Decimal. The product is computed exactly using a 128 bit
+// intermediate and truncated at the 8th decimal place. Mul panics if the result overflows.
 func (f Decimal) Mul(f0 Decimal) Decimal {
-	fp_a := f.fp / scale
-	fp_b := f.fp % scale
-
-	fp0_a := f0.fp / scale
-	fp0_b := f0.fp % scale
-
-	var result uint64
-
-	if fp0_a != 0 {
-		result = fp_a * fp0_a
-		if float64(result) > MAX {
-			panic("decimal overflow")
-		}
-
-		result = result*scale + fp_b*fp0_a
-	}
+	return f.MulRound(f0, RoundDown)
+}
 
-	if fp0_b != 0 {
-		result = result + (fp_a * fp0_b) + ((fp_b)*fp0_b)/scale
+// MulRound multiplies f by f0 like Mul, rounding the exact product to 8 decimal places using the specified
+// rounding mode. MulRound panics if the result overflows.
+func (f Decimal) MulRound(f0 Decimal, mode RoundMode) Decimal {
+	fp, err := mulUnits(f.fp, f0.fp, scale, mode)
+	if err != nil {
+		panic("decimal overflow")
 	}
-
-	return Decimal{fp: result}
+	return Decimal{fp: fp}
 }
 
-// Div divides f by f0 returning a Decimal.
+// Div divides f by f0 returning a Decimal. The quotient is computed exactly using a 128 bit
+// intermediate and rounded half-up at the 8th decimal place. Div panics if f0 is zero or if the
+// result overflows.
 func (f Decimal) Div(f0 Decimal) Decimal {
-	res, err := ParseFloat(f.Float() / f0.Float())
+	fp, err := divUnits(f.fp, f0.fp, scale, RoundHalfUp)
 	if err != nil {
+		if err == ErrDivisionByZero {
+			panic("decimal division by zero")
+		}
 		panic("decimal overflow")
 	}
-	return res
+	return Decimal{fp: fp}
 }
 
-// Round returns a rounded (half-up, away from zero) to n decimal places
+// Round returns f rounded (half-up, away from zero) to n decimal places
 func (f Decimal) Round(n int) Decimal {
-	round := .5
-	if f.fp < 0 {
-		round = -0.5
-	}
-
-	f0 := f.Frac()
-	f0 = f0*math.Pow10(n) + round
-	f0 = float64(int(f0)) / math.Pow10(n)
-
-	res, err := ParseFloat(float64(f.Int()) + f0)
-	if err != nil {
-		panic("decimal overflow")
-	}
-	return res
+	return f.RoundWith(n, RoundHalfUp)
 }
 
 // Equal returns true if the f == f0.
@@ -263,48 +278,92 @@ func (f Decimal) Cmp(f0 Decimal) int {
 
 // String converts a Decimal to a string, dropping trailing zeros
 func (f Decimal) String() string {
-	s, point := f.tostr()
-	if point == -1 {
-		return s
-	}
-	index := len(s) - 1
-	for ; index != point; index-- {
-		if s[index] != '0' {
-			return s[:index+1]
-		}
-	}
-	return s[:point]
+	var buffer [24]byte
+	return string(f.AppendString(buffer[:0]))
 }
 
-// StringN converts a Decimal to a String with a specified number of decimal places, truncating as required
+// StringN converts a Decimal to a String with a specified number of decimal places, truncating as required.
+// Zeros are appended if decimals is more than 8.
 func (f Decimal) StringN(decimals int) string {
-	s, point := f.tostr()
+	var buffer [32]byte
+	return string(f.AppendStringN(buffer[:0], decimals))
+}
 
-	if point == -1 {
-		return s
-	}
-	if decimals == 0 {
-		return s[:point]
-	} else {
-		return s[:point+decimals+1]
+// AppendString appends the string form of f, as returned by String, to dst and returns the extended buffer
+func (f Decimal) AppendString(dst []byte) []byte {
+	return appendUnits(dst, f.fp, nPlaces)
+}
+
+// AppendStringN appends f with the specified number of decimal places, as returned by StringN, to dst and
+// returns the extended buffer
+func (f Decimal) AppendStringN(dst []byte, decimals int) []byte {
+	return appendUnitsN(dst, f.fp, nPlaces, decimals)
+}
+
+// AppendText implements the encoding.TextAppender interface, appending the string form of f to b
+func (f Decimal) AppendText(b []byte) ([]byte, error) {
+	return f.AppendString(b), nil
+}
+
+// appendUnits appends a fixed point value with the given number of decimal places to dst, dropping
+// trailing zeros
+func appendUnits(dst []byte, fp uint64, places int) []byte {
+	if places == 0 {
+		return strconv.AppendUint(dst, fp, 10)
 	}
+	var buffer [24]byte
+	return appendTrimmed(dst, itoa(buffer[:], fp, places))
 }
 
-func (f Decimal) tostr() (string, int) {
-	fp := f.fp
-	if fp == 0 {
-		return "0." + zeros, 1
+// appendUnitsN appends a fixed point value with the given number of decimal places to dst, truncated or
+// padded to decimals places
+func appendUnitsN(dst []byte, fp uint64, places int, decimals int) []byte {
+	if places == 0 {
+		return appendTruncated(strconv.AppendUint(dst, fp, 10), nil, 0, decimals)
 	}
+	var buffer [24]byte
+	return appendTruncated(dst, itoa(buffer[:], fp, places), places, decimals)
+}
 
-	b := make([]byte, 24)
-	b = itoa(b, fp)
+// appendTrimmed appends the formatted number b, which has a decimal point, to dst dropping trailing zeros
+func appendTrimmed(dst []byte, b []byte) []byte {
+	end := len(b)
+	for b[end-1] == '0' {
+		end--
+	}
+	if b[end-1] == '.' {
+		end--
+	}
+	return append(dst, b[:end]...)
+}
 
-	return string(b), len(b) - nPlaces - 1
+// appendTruncated appends the formatted number b, which has the given number of decimal places after its
+// decimal point, to dst truncated or padded with zeros to decimals places
+func appendTruncated(dst []byte, b []byte, places int, decimals int) []byte {
+	point := len(b) - places - 1
+	if decimals <= 0 {
+		if places == 0 {
+			return append(dst, b...)
+		}
+		return append(dst, b[:point]...)
+	}
+	if decimals <= places {
+		return append(dst, b[:point+decimals+1]...)
+	}
+	if places == 0 {
+		dst = append(append(dst, b...), '.')
+	} else {
+		dst = append(dst, b...)
+	}
+	for i := places; i < decimals; i++ {
+		dst = append(dst, '0')
+	}
+	return dst
 }
 
-func itoa(buf []byte, val uint64) []byte {
+func itoa(buf []byte, val uint64, places int) []byte {
 	i := len(buf) - 1
-	idec := i - nPlaces
+	idec := i - places
 	for val >= 10 || i >= idec {
 		buf[i] = byte(val%10 + '0')
 		i--
@@ -369,14 +428,26 @@ func ReadFrom(r io.ByteReader) (Decimal, error) {
 	return Decimal{fp: fp}, nil
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (f *Decimal) UnmarshalText(text []byte) error {
+	decimal, err := Parse(string(text))
+	*f = decimal
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the String form of f
+func (f Decimal) MarshalText() ([]byte, error) {
+	return f.AppendString(nil), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both JSON numbers and strings are accepted.
 func (f *Decimal) UnmarshalJSON(bytes []byte) error {
 	s := string(bytes)
 	if s == "null" {
 		return nil
 	}
 
-	decimal, err := Parse(s)
+	decimal, err := Parse(unquoteJSON(s))
 	*f = decimal
 	if err != nil {
 		return fmt.Errorf("Error decoding string '%s': %s", s, err)
@@ -386,6 +457,27 @@ func (f *Decimal) UnmarshalJSON(bytes []byte) error {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (f Decimal) MarshalJSON() ([]byte, error) {
-	buffer := make([]byte, 24)
-	return itoa(buffer, f.fp), nil
+	return f.AppendStringN(make([]byte, 0, 24), nPlaces), nil
+}
+
+// unquoteJSON removes the quotes from a JSON string, returning other JSON values unchanged
+func unquoteJSON(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// StringDecimal is a Decimal that is marshalled to JSON as a string without trailing zeros, such as "1.5",
+// which clients that decode JSON numbers as floats can read without losing precision
+type StringDecimal struct {
+	Decimal
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f StringDecimal) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 1, 26)
+	b[0] = '"'
+	b = f.AppendString(b)
+	return append(b, '"'), nil
 }
@@ -0,0 +1,514 @@
+package udecimal
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Decimal128 is a decimal with the same 8 decimal places as Decimal, stored in 128 bits. It is intended
+// for aggregates such as turnover and notional totals that can exceed MAX. The maximum permitted value is
+// just over 3.4 * 10^30.
+type Decimal128 struct {
+	hi, lo uint64
+}
+
+var Zero128 = Decimal128{}
+
+// maxUvarint128Len is the maximum length of a 128 bit varint
+const maxUvarint128Len = 19
+
+// ParseDecimal128 creates a Decimal128 from a string. It accepts the same syntax as Parse, including
+// exponents, and likewise truncates fractional digits beyond the 8th decimal place.
+func ParseDecimal128(s string) (Decimal128, error) {
+	hi, lo, err := scanUnits128(s)
+	if err != nil {
+		return Zero128, err
+	}
+	return Decimal128{hi: hi, lo: lo}, nil
+}
+
+// scanUnits128 is scanUnits in non-strict mode for a 128 bit fixed point value with 8 decimal places. The
+// structure of the input is found in one pass and the significant digits are then accumulated, as
+// scanExponent does.
+func scanUnits128(s string) (hi, lo uint64, err error) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	intEnd := i
+	fracStart, fracEnd := i, i
+	if i < len(s) && s[i] == '.' {
+		i++
+		fracStart = i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		fracEnd = i
+	}
+	if intEnd == 0 && fracEnd == fracStart {
+		return 0, 0, errCannotParse
+	}
+	exp := 0
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		var ok bool
+		if exp, i, ok = scanExponentValue(s, i); !ok {
+			return 0, 0, errCannotParse
+		}
+	}
+	if i < len(s) {
+		return 0, 0, errCannotParse
+	}
+
+	// the last mantissa digit is worth 10^shift units
+	shift := exp - (fracEnd - fracStart) + nPlaces
+	digits := intEnd + (fracEnd - fracStart)
+
+	for k := 0; k < digits; k++ {
+		if shift+digits-1-k < 0 {
+			// truncated
+			break
+		}
+		offset := k
+		if offset >= intEnd {
+			offset = fracStart + k - intEnd
+		}
+		var ok bool
+		if hi, lo, ok = mulAdd128(hi, lo, 10, uint64(s[offset]-'0')); !ok {
+			return 0, 0, errTooLarge
+		}
+	}
+
+	if hi|lo != 0 {
+		for ; shift > 0; shift-- {
+			var ok bool
+			if hi, lo, ok = mulAdd128(hi, lo, 10, 0); !ok {
+				return 0, 0, errTooLarge
+			}
+		}
+	}
+	return hi, lo, nil
+}
+
+// MustParseDecimal128 creates a new Decimal128 from a string, and panics if the string could not be parsed
+func MustParseDecimal128(s string) Decimal128 {
+	f, err := ParseDecimal128(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// mulAdd128 returns hi:lo * m + a, and false if the result does not fit in 128 bits
+func mulAdd128(hi, lo, m, a uint64) (uint64, uint64, bool) {
+	h1, l1 := bits.Mul64(lo, m)
+	h2, l2 := bits.Mul64(hi, m)
+	h, c := bits.Add64(h1, l2, 0)
+	if h2 != 0 || c != 0 {
+		return 0, 0, false
+	}
+	l, c := bits.Add64(l1, a, 0)
+	h, c = bits.Add64(h, 0, c)
+	if c != 0 {
+		return 0, 0, false
+	}
+	return h, l, true
+}
+
+// Wide converts f to a Decimal128
+func (f Decimal) Wide() Decimal128 {
+	return Decimal128{lo: f.fp}
+}
+
+// Narrow converts f to a Decimal, returning ErrOverflow if f is larger than MAX
+func (f Decimal128) Narrow() (Decimal, error) {
+	if f.hi != 0 || f.lo > maxFP {
+		return Zero, ErrOverflow
+	}
+	return Decimal{fp: f.lo}, nil
+}
+
+func (f Decimal128) IsZero() bool {
+	return f.hi == 0 && f.lo == 0
+}
+
+// Float converts the Decimal128 to a float64
+func (f Decimal128) Float() float64 {
+	return (float64(f.hi)*(1<<64) + float64(f.lo)) / float64(scale)
+}
+
+// Add adds f0 to f producing a Decimal128.
+func (f Decimal128) Add(f0 Decimal128) Decimal128 {
+	lo, c := bits.Add64(f.lo, f0.lo, 0)
+	hi, c := bits.Add64(f.hi, f0.hi, c)
+	if c != 0 {
+		panic("decimal overflow")
+	}
+	return Decimal128{hi: hi, lo: lo}
+}
+
+// Sub subtracts f0 from f producing a Decimal128.
+func (f Decimal128) Sub(f0 Decimal128) Decimal128 {
+	lo, b := bits.Sub64(f.lo, f0.lo, 0)
+	hi, b := bits.Sub64(f.hi, f0.hi, b)
+	if b != 0 {
+		panic("decimal overflow")
+	}
+	return Decimal128{hi: hi, lo: lo}
+}
+
+// Mul multiplies f by f0 returning a Decimal128, truncating at the 8th decimal place.
+func (f Decimal128) Mul(f0 Decimal128) Decimal128 {
+	// 256 bit product p3:p2:p1:p0
+	h00, p0 := bits.Mul64(f.lo, f0.lo)
+	h01, l01 := bits.Mul64(f.lo, f0.hi)
+	h10, l10 := bits.Mul64(f.hi, f0.lo)
+	h11, l11 := bits.Mul64(f.hi, f0.hi)
+
+	p1, c1 := bits.Add64(h00, l01, 0)
+	p1, c2 := bits.Add64(p1, l10, 0)
+	p2, c3 := bits.Add64(h01, h10, c1)
+	p2, c4 := bits.Add64(p2, l11, c2)
+	p3 := h11 + c3 + c4
+
+	if p3 >= scale {
+		panic("decimal overflow")
+	}
+	q2, r := bits.Div64(p3, p2, scale)
+	if q2 != 0 {
+		panic("decimal overflow")
+	}
+	q1, r := bits.Div64(r, p1, scale)
+	q0, _ := bits.Div64(r, p0, scale)
+	return Decimal128{hi: q1, lo: q0}
+}
+
+// Div divides f by f0 returning a Decimal128, rounded half-up at the 8th decimal place. Div panics if
+// f0 is zero or if the result overflows.
+func (f Decimal128) Div(f0 Decimal128) Decimal128 {
+	if f0.IsZero() {
+		panic("decimal division by zero")
+	}
+
+	// 192 bit numerator n2:n1:n0
+	n1, n0 := bits.Mul64(f.lo, scale)
+	n2, l := bits.Mul64(f.hi, scale)
+	n1, c := bits.Add64(n1, l, 0)
+	n2 += c
+
+	var qhi, qlo, rhi, rlo uint64
+	if f0.hi == 0 {
+		if n2 >= f0.lo {
+			panic("decimal overflow")
+		}
+		qhi, rlo = bits.Div64(n2, n1, f0.lo)
+		qlo, rlo = bits.Div64(rlo, n0, f0.lo)
+	} else {
+		qhi, qlo, rhi, rlo = div192by128(n2, n1, n0, f0.hi, f0.lo)
+	}
+
+	// round half-up, comparing the remainder with the divisor less the remainder
+	dlo, b := bits.Sub64(f0.lo, rlo, 0)
+	dhi, _ := bits.Sub64(f0.hi, rhi, b)
+	if rhi > dhi || rhi == dhi && rlo >= dlo {
+		var c uint64
+		qlo, c = bits.Add64(qlo, 1, 0)
+		qhi, c = bits.Add64(qhi, 0, c)
+		if c != 0 {
+			panic("decimal overflow")
+		}
+	}
+	return Decimal128{hi: qhi, lo: qlo}
+}
+
+// div192by128 divides n2:n1:n0 by d1:d0 where d1 is non-zero using binary long division. The quotient
+// always fits in 128 bits.
+func div192by128(n2, n1, n0, d1, d0 uint64) (q1, q0, r1, r0 uint64) {
+	// the top 64 bits are always less than the divisor so start with them as the remainder
+	r1, r0 = 0, n2
+	for i := 127; i >= 0; i-- {
+		var next uint64
+		if i >= 64 {
+			next = n1 >> uint(i-64) & 1
+		} else {
+			next = n0 >> uint(i) & 1
+		}
+		carry := r1 >> 63
+		r1 = r1<<1 | r0>>63
+		r0 = r0<<1 | next
+
+		if carry != 0 || r1 > d1 || r1 == d1 && r0 >= d0 {
+			var b uint64
+			r0, b = bits.Sub64(r0, d0, 0)
+			r1, _ = bits.Sub64(r1, d1, b)
+			if i >= 64 {
+				q1 |= 1 << uint(i-64)
+			} else {
+				q0 |= 1 << uint(i)
+			}
+		}
+	}
+	return q1, q0, r1, r0
+}
+
+// Equal returns true if the f == f0.
+func (f Decimal128) Equal(f0 Decimal128) bool {
+	return f == f0
+}
+
+// GreaterThan returns true if the f > f0.
+func (f Decimal128) GreaterThan(f0 Decimal128) bool {
+	return f.Cmp(f0) > 0
+}
+
+// GreaterThanOrEqual returns true if the f >= f0.
+func (f Decimal128) GreaterThanOrEqual(f0 Decimal128) bool {
+	return f.Cmp(f0) >= 0
+}
+
+// LessThan returns true if the f < f0.
+func (f Decimal128) LessThan(f0 Decimal128) bool {
+	return f.Cmp(f0) < 0
+}
+
+// LessThanOrEqual returns true if the f <= f0.
+func (f Decimal128) LessThanOrEqual(f0 Decimal128) bool {
+	return f.Cmp(f0) <= 0
+}
+
+// Cmp compares two Decimal128. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1.
+func (f Decimal128) Cmp(f0 Decimal128) int {
+	switch {
+	case f.hi < f0.hi:
+		return -1
+	case f.hi > f0.hi:
+		return 1
+	case f.lo < f0.lo:
+		return -1
+	case f.lo > f0.lo:
+		return 1
+	}
+	return 0
+}
+
+// String converts a Decimal128 to a string, dropping trailing zeros
+func (f Decimal128) String() string {
+	var buffer [48]byte
+	return string(f.AppendString(buffer[:0]))
+}
+
+// StringN converts a Decimal128 to a String with a specified number of decimal places, truncating as required
+func (f Decimal128) StringN(decimals int) string {
+	var buffer [48]byte
+	return string(f.AppendStringN(buffer[:0], decimals))
+}
+
+// AppendString appends the string form of f, as returned by String, to dst and returns the extended buffer
+func (f Decimal128) AppendString(dst []byte) []byte {
+	var buffer [48]byte
+	return appendTrimmed(dst, f.itoa(buffer[:]))
+}
+
+// AppendStringN appends f with the specified number of decimal places, as returned by StringN, to dst and
+// returns the extended buffer
+func (f Decimal128) AppendStringN(dst []byte, decimals int) []byte {
+	var buffer [48]byte
+	return appendTruncated(dst, f.itoa(buffer[:]), nPlaces, decimals)
+}
+
+// AppendText implements the encoding.TextAppender interface, appending the string form of f to b
+func (f Decimal128) AppendText(b []byte) ([]byte, error) {
+	return f.AppendString(b), nil
+}
+
+// Format implements the fmt.Formatter interface. See Decimal.Format for the supported verbs.
+func (f Decimal128) Format(s fmt.State, verb rune) {
+	var buffer [48]byte
+	formatDecimal(s, verb, "udecimal.Decimal128", false, f.AppendString(buffer[:0]))
+}
+
+// itoa formats f with all 8 decimal places into the end of buf
+func (f Decimal128) itoa(buf []byte) []byte {
+	const chunk = 10000000000000000000 // 10^19, the largest power of 10 that fits in a uint64
+
+	q1, r := bits.Div64(0, f.hi, scale)
+	q0, frac := bits.Div64(r, f.lo, scale)
+
+	i := len(buf)
+	for n := 0; n < nPlaces; n++ {
+		i--
+		buf[i] = byte(frac%10 + '0')
+		frac /= 10
+	}
+	i--
+	buf[i] = '.'
+
+	for {
+		var c uint64
+		q1, r = bits.Div64(0, q1, chunk)
+		q0, c = bits.Div64(r, q0, chunk)
+		if q1 == 0 && q0 == 0 {
+			// the leading chunk is written without zero padding
+			for {
+				i--
+				buf[i] = byte(c%10 + '0')
+				c /= 10
+				if c == 0 {
+					return buf[i:]
+				}
+			}
+		}
+		for n := 0; n < 19; n++ {
+			i--
+			buf[i] = byte(c%10 + '0')
+			c /= 10
+		}
+	}
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (f *Decimal128) UnmarshalBinary(data []byte) error {
+	hi, lo, n := uvarint128(data)
+	if n <= 0 {
+		return errFormat
+	}
+	f.hi, f.lo = hi, lo
+	return nil
+}
+
+// UnmarshalBinaryData Unmarshals data and returns n
+func (f *Decimal128) UnmarshalBinaryData(data []byte) (rem []byte, err error) {
+	hi, lo, n := uvarint128(data)
+	if n <= 0 {
+		return data, errFormat
+	}
+	f.hi, f.lo = hi, lo
+	return data[n:], nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f Decimal128) MarshalBinary() (data []byte, err error) {
+	var buffer [maxUvarint128Len]byte
+	n := putUvarint128(buffer[:], f.hi, f.lo)
+	return buffer[:n], nil
+}
+
+// WriteTo write the Decimal128 to an io.Writer, returning the number of bytes written
+func (f Decimal128) WriteTo(w io.ByteWriter) error {
+	hi, lo := f.hi, f.lo
+	for hi != 0 || lo >= 0x80 {
+		err := w.WriteByte(byte(lo) | 0x80)
+		if err != nil {
+			return err
+		}
+		lo = lo>>7 | hi<<57
+		hi >>= 7
+	}
+	return w.WriteByte(byte(lo))
+}
+
+// ReadDecimal128From reads a Decimal128 from an io.Reader
+func ReadDecimal128From(r io.ByteReader) (Decimal128, error) {
+	var hi, lo uint64
+	for i := 0; i < maxUvarint128Len; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			if i > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return Zero128, err
+		}
+		var ok bool
+		hi, lo, ok = setUvarint128Group(hi, lo, i, b)
+		if !ok {
+			return Zero128, errFormat
+		}
+		if b < 0x80 {
+			return Decimal128{hi: hi, lo: lo}, nil
+		}
+	}
+	return Zero128, errFormat
+}
+
+// putUvarint128 encodes hi:lo into buf as a varint and returns the number of bytes written
+func putUvarint128(buf []byte, hi, lo uint64) int {
+	i := 0
+	for hi != 0 || lo >= 0x80 {
+		buf[i] = byte(lo) | 0x80
+		lo = lo>>7 | hi<<57
+		hi >>= 7
+		i++
+	}
+	buf[i] = byte(lo)
+	return i + 1
+}
+
+// uvarint128 decodes a varint from buf returning the value and the number of bytes read, or n <= 0
+// on error
+func uvarint128(buf []byte) (hi, lo uint64, n int) {
+	for i, b := range buf {
+		if i == maxUvarint128Len {
+			return 0, 0, -(i + 1)
+		}
+		var ok bool
+		hi, lo, ok = setUvarint128Group(hi, lo, i, b)
+		if !ok {
+			return 0, 0, -(i + 1)
+		}
+		if b < 0x80 {
+			return hi, lo, i + 1
+		}
+	}
+	return 0, 0, 0
+}
+
+// setUvarint128Group adds the 7 bit group b at index i to hi:lo, returning false if it overflows 128 bits
+func setUvarint128Group(hi, lo uint64, i int, b byte) (uint64, uint64, bool) {
+	v := uint64(b & 0x7f)
+	shift := uint(7 * i)
+	if i == maxUvarint128Len-1 && v > 3 {
+		return 0, 0, false
+	}
+	switch {
+	case shift < 64:
+		lo |= v << shift
+		if shift > 57 {
+			hi |= v >> (64 - shift)
+		}
+	default:
+		hi |= v << (shift - 64)
+	}
+	return hi, lo, true
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (f *Decimal128) UnmarshalText(text []byte) error {
+	decimal, err := ParseDecimal128(string(text))
+	*f = decimal
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the String form of f
+func (f Decimal128) MarshalText() ([]byte, error) {
+	return f.AppendString(nil), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both JSON numbers and strings are accepted.
+func (f *Decimal128) UnmarshalJSON(bytes []byte) error {
+	s := string(bytes)
+	if s == "null" {
+		return nil
+	}
+
+	decimal, err := ParseDecimal128(unquoteJSON(s))
+	*f = decimal
+	if err != nil {
+		return fmt.Errorf("Error decoding string '%s': %s", s, err)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f Decimal128) MarshalJSON() ([]byte, error) {
+	return f.AppendStringN(make([]byte, 0, 48), nPlaces), nil
+}
@@ -0,0 +1,277 @@
+package udecimal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var max128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// random128 returns random units spread evenly over the magnitudes of the Decimal128 range
+func random128(rnd *rand.Rand) *big.Int {
+	n := new(big.Int).Lsh(big.NewInt(1), uint(rnd.Intn(128)+1))
+	return n.Rand(rnd, n)
+}
+
+func units128String(u *big.Int) string {
+	return decimal.NewFromBigInt(u, -8).String()
+}
+
+func TestDecimal128Parse(t *testing.T) {
+	f := MustParseDecimal128("123.456")
+	assert.Equal(t, "123.456", f.String())
+
+	f = MustParseDecimal128("3402823669209384634633746.07431768211455")
+	assert.Equal(t, "3402823669209384634633746.07431768", f.String())
+
+	f = MustParseDecimal128("3402823669209384634633746074317.68211455")
+	assert.Equal(t, "3402823669209384634633746074317.68211455", f.String())
+
+	f = MustParseDecimal128(".5")
+	assert.Equal(t, "0.5", f.String())
+
+	f = MustParseDecimal128("100")
+	assert.Equal(t, "100", f.String())
+
+	f = MustParseDecimal128("0")
+	assert.Equal(t, "0", f.String())
+	assert.True(t, f.IsZero())
+
+	_, err := ParseDecimal128("3402823669209384634633746074317.68211456")
+	assert.Error(t, err)
+	_, err = ParseDecimal128("100000000000000000000000000000000")
+	assert.Error(t, err)
+	_, err = ParseDecimal128("")
+	assert.Error(t, err)
+	_, err = ParseDecimal128("1,5")
+	assert.Error(t, err)
+	_, err = ParseDecimal128("1.5x")
+	assert.Error(t, err)
+	_, err = ParseDecimal128("-1")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustParseDecimal128("abc") })
+}
+
+func TestDecimal128ParseSyntax(t *testing.T) {
+	// the same syntax as Parse
+	for _, s := range []string{"0", "123", ".5", "5.", "1.123456789", "1.5e3", "15E-1", "1e+2", "1.123456789e1", "1.1234567899e0", "100000000000e-1", "1e-9", "0e10000"} {
+		expected, err := Parse(s)
+		assert.NoError(t, err, s)
+		f, err := ParseDecimal128(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, expected.Wide(), f, s)
+		}
+	}
+	for _, s := range []string{".", "e5", ".e5", "1e", "1e+", "1e3.5", "1.2.3", "+1", " 1", "1_000", "NaN", "1e3x"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+		_, err = ParseDecimal128(s)
+		assert.Error(t, err, s)
+	}
+
+	// exponents beyond the range of Decimal
+	f, err := ParseDecimal128("1e30")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000000000000", f.String())
+	f, err = ParseDecimal128("3.4028236692093846346337460743176821145e30")
+	assert.NoError(t, err)
+	assert.Equal(t, "3402823669209384634633746074317.68211450", f.StringN(8))
+	f, err = ParseDecimal128("123456789012345678901234.123456789e-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890123456789012.34123456", f.String())
+
+	for _, s := range []string{"4e30", "1e31", "1e10000", "0.00000001e39"} {
+		_, err = ParseDecimal128(s)
+		assert.Error(t, err, s)
+	}
+
+	var j J128Struct
+	assert.NoError(t, json.Unmarshal([]byte(`{"f":1e3}`), &j))
+	assert.Equal(t, "1000", j.F.String())
+}
+
+func TestDecimal128WideNarrow(t *testing.T) {
+	f := MustParse("12345.6789")
+	assert.Equal(t, "12345.6789", f.Wide().String())
+
+	n, err := f.Wide().Narrow()
+	assert.NoError(t, err)
+	assert.Equal(t, f, n)
+
+	n, err = Max.Wide().Narrow()
+	assert.NoError(t, err)
+	assert.Equal(t, Max, n)
+
+	_, err = Max.Wide().Add(SmallestUnit.Wide()).Narrow()
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = MustParseDecimal128("1000000000000000").Narrow()
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestDecimal128Aggregate(t *testing.T) {
+	sum := Zero128
+	for i := 0; i < 1000; i++ {
+		sum = sum.Add(Max.Wide())
+	}
+	assert.Equal(t, "99999999999999.99999", sum.String())
+
+	notional := Max.Wide().Mul(Max.Wide())
+	assert.Equal(t, "9999999999999999998000", notional.String())
+}
+
+func TestDecimal128Arithmetic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(4))
+	s := big.NewInt(100000000)
+
+	for i := 0; i < 20000; i++ {
+		a := random128(rnd)
+		b := random128(rnd)
+		f0 := MustParseDecimal128(units128String(a))
+		f1 := MustParseDecimal128(units128String(b))
+
+		check := func(op string, expected *big.Int, fn func() Decimal128) {
+			if expected.Sign() < 0 || expected.Cmp(max128) > 0 {
+				assert.Panics(t, func() { fn() }, "%s %s %s", f0, op, f1)
+				return
+			}
+			assert.Equal(t, units128String(expected), fn().String(), "%s %s %s", f0, op, f1)
+		}
+
+		check("+", new(big.Int).Add(a, b), func() Decimal128 { return f0.Add(f1) })
+		check("-", new(big.Int).Sub(a, b), func() Decimal128 { return f0.Sub(f1) })
+		check("*", new(big.Int).Quo(new(big.Int).Mul(a, b), s), func() Decimal128 { return f0.Mul(f1) })
+		if b.Sign() != 0 {
+			// half-up: (2 * a * scale + b) / (2 * b)
+			n := new(big.Int).Mul(a, s)
+			n.Lsh(n, 1).Add(n, b)
+			check("/", n.Quo(n, new(big.Int).Lsh(b, 1)), func() Decimal128 { return f0.Div(f1) })
+		}
+		assert.Equal(t, a.Cmp(b), f0.Cmp(f1))
+	}
+}
+
+func TestDecimal128Div(t *testing.T) {
+	f0 := MustParseDecimal128("2")
+	f1 := MustParseDecimal128("3")
+	assert.Equal(t, "0.66666667", f0.Div(f1).String())
+
+	f0 = MustParseDecimal128("1000000000000000000000000")
+	f1 = MustParseDecimal128("300000000000000000000")
+	assert.Equal(t, "3333.33333333", f0.Div(f1).String())
+
+	assert.Panics(t, func() { f0.Div(Zero128) })
+	assert.Panics(t, func() { MustParseDecimal128("1000000000000000000000000").Div(MustParseDecimal128("0.00000001")) })
+}
+
+func TestDecimal128Compare(t *testing.T) {
+	a := MustParseDecimal128("100000000000000000000")
+	b := MustParseDecimal128("99999999999999999999.99999999")
+
+	assert.True(t, a.GreaterThan(b))
+	assert.True(t, a.GreaterThanOrEqual(b))
+	assert.True(t, b.LessThan(a))
+	assert.True(t, b.LessThanOrEqual(a))
+	assert.True(t, a.Equal(MustParseDecimal128("100000000000000000000.0")))
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(a))
+	assert.Equal(t, 1e20, a.Float())
+}
+
+func TestDecimal128EncodeDecode(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+
+	values := []Decimal128{Zero128, MustParseDecimal128("1"), MustParseDecimal128("3402823669209384634633746074317.68211455")}
+	for i := 0; i < 1000; i++ {
+		values = append(values, MustParseDecimal128(units128String(random128(rnd))))
+	}
+
+	for _, f := range values {
+		b := &bytes.Buffer{}
+		assert.NoError(t, f.WriteTo(b))
+		f0, err := ReadDecimal128From(b)
+		assert.NoError(t, err)
+		assert.Equal(t, f, f0)
+
+		data, err := f.MarshalBinary()
+		assert.NoError(t, err)
+		var f1 Decimal128
+		assert.NoError(t, f1.UnmarshalBinary(data))
+		assert.Equal(t, f, f1)
+
+		rem, err := f1.UnmarshalBinaryData(append(data, 7))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{7}, rem)
+	}
+
+	var f Decimal128
+	assert.Error(t, f.UnmarshalBinary(nil))
+	assert.Error(t, f.UnmarshalBinary([]byte{0x80}))
+	assert.Error(t, f.UnmarshalBinary(bytes.Repeat([]byte{0xff}, 19)))
+
+	_, err := ReadDecimal128From(bytes.NewReader([]byte{0x80}))
+	assert.Error(t, err)
+}
+
+type J128Struct struct {
+	F Decimal128 `json:"f"`
+}
+
+func TestDecimal128JSON(t *testing.T) {
+	j := J128Struct{F: MustParseDecimal128("123456789012345678901234.5")}
+
+	data, err := json.Marshal(&j)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"f":123456789012345678901234.50000000}`, string(data))
+
+	var j0 J128Struct
+	assert.NoError(t, json.Unmarshal(data, &j0))
+	assert.Equal(t, j, j0)
+	assert.NoError(t, json.Unmarshal([]byte(`{"f":"123456789012345678901234.5"}`), &j0))
+	assert.Equal(t, j, j0)
+
+	text, err := j.F.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012345678901234.5", string(text))
+	assert.NoError(t, j0.F.UnmarshalText(text))
+	assert.Equal(t, j, j0)
+}
+
+func TestDecimal128Allocs(t *testing.T) {
+	f0 := MustParseDecimal128("123456789012345678901234.5")
+	f1 := MustParseDecimal128("12345678901234567890.12345678")
+	f2 := MustParseDecimal128("3.5")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		f0.Add(f1)
+		f0.Sub(f1)
+		f1.Mul(f2)
+		f0.Div(f1)
+		f0.Div(f2)
+		f0.Cmp(f1)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestDecimal128AppendString(t *testing.T) {
+	f := MustParseDecimal128("123456789012345678901234.5")
+	buf := []byte("total=")
+
+	assert.Equal(t, "total=123456789012345678901234.5", string(f.AppendString(buf)))
+	assert.Equal(t, "total=123456789012345678901234.50", string(f.AppendStringN(buf, 2)))
+	assert.Equal(t, "123456789012345678901234", f.StringN(0))
+
+	b, err := f.AppendText(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "total=123456789012345678901234.5", string(b))
+}
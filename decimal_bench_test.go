@@ -3,6 +3,8 @@ package udecimal
 import (
 	"bytes"
 	"math/big"
+	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -42,15 +44,23 @@ func BenchmarkAddBigFloat(b *testing.B) {
 }
 
 func BenchmarkMulDecimal(b *testing.B) {
-	f0 := MustParseFloat(123456789.0)
+	f0 := MustParseFloat(12345678.9)
 	f1 := MustParseFloat(1234.0)
 
 	for i := 0; i < b.N; i++ {
 		f0.Mul(f1)
 	}
 }
+func BenchmarkMulRoundDecimal(b *testing.B) {
+	f0 := MustParseFloat(12345678.9)
+	f1 := MustParseFloat(0.0006)
+
+	for i := 0; i < b.N; i++ {
+		parsed = f0.MulRound(f1, RoundHalfEven)
+	}
+}
 func BenchmarkMulShopspringDecimal(b *testing.B) {
-	f0 := decimal.NewFromFloat(123456789.0)
+	f0 := decimal.NewFromFloat(12345678.9)
 	f1 := decimal.NewFromFloat(1234.0)
 
 	for i := 0; i < b.N; i++ {
@@ -144,11 +154,13 @@ func BenchmarkCmpBigFloat(b *testing.B) {
 	}
 }
 
+var str string
+
 func BenchmarkStringDecimal(b *testing.B) {
 	f0 := MustParseFloat(123456789.12345)
 
 	for i := 0; i < b.N; i++ {
-		f0.String()
+		str = f0.String()
 	}
 }
 func BenchmarkStringNDecimal(b *testing.B) {
@@ -158,25 +170,83 @@ func BenchmarkStringNDecimal(b *testing.B) {
 		f0.StringN(5)
 	}
 }
+func BenchmarkAppendStringDecimal(b *testing.B) {
+	f0 := MustParseFloat(123456789.12345)
+	buf := make([]byte, 0, 24)
+
+	for i := 0; i < b.N; i++ {
+		buf = f0.AppendString(buf[:0])
+	}
+}
+func BenchmarkAppendStringNDecimal(b *testing.B) {
+	f0 := MustParseFloat(123456789.12345)
+	buf := make([]byte, 0, 24)
+
+	for i := 0; i < b.N; i++ {
+		buf = f0.AppendStringN(buf[:0], 5)
+	}
+}
 func BenchmarkStringShopspringDecimal(b *testing.B) {
 	f0 := decimal.NewFromFloat(123456789.12345)
 
 	for i := 0; i < b.N; i++ {
-		f0.String()
+		str = f0.String()
 	}
 }
 func BenchmarkStringBigInt(b *testing.B) {
 	f0 := big.NewInt(123456789)
 
 	for i := 0; i < b.N; i++ {
-		f0.String()
+		str = f0.String()
 	}
 }
 func BenchmarkStringBigFloat(b *testing.B) {
 	f0 := big.NewFloat(123456789.12345)
 
 	for i := 0; i < b.N; i++ {
-		f0.String()
+		str = f0.String()
+	}
+}
+
+var parsed Decimal
+
+func BenchmarkParseDecimal(b *testing.B) {
+	s := "123456789.12345"
+
+	for i := 0; i < b.N; i++ {
+		parsed, _ = Parse(s)
+	}
+}
+func BenchmarkParseBytesDecimal(b *testing.B) {
+	s := []byte("123456789.12345")
+
+	for i := 0; i < b.N; i++ {
+		parsed, _ = ParseBytes(s)
+	}
+}
+func BenchmarkParseStrconvFloat(b *testing.B) {
+	s := "123456789.12345"
+
+	for i := 0; i < b.N; i++ {
+		f, _ := strconv.ParseFloat(s, 64)
+		parsed, _ = ParseFloat(f)
+	}
+}
+func BenchmarkParseStrconvUint(b *testing.B) {
+	s := "123456789"
+	fs := "12345000"
+
+	for i := 0; i < b.N; i++ {
+		n, _ := strconv.ParseUint(s, 10, 64)
+		f, _ := strconv.ParseUint(fs, 10, 64)
+		parsed = NewI(n*scale+f, nPlaces)
+	}
+}
+func BenchmarkParseShopspringDecimal(b *testing.B) {
+	s := "123456789.12345"
+
+	for i := 0; i < b.N; i++ {
+		decimal.NewFromString(s)
 	}
 }
 
@@ -236,3 +306,167 @@ func BenchmarkGreaterThanOrEqualDecimal(b *testing.B) {
 		res = f1.GreaterThanOrEqual(f0)
 	}
 }
+
+// bookLevels returns n sorted price levels a tick apart with occasional gaps, as in an order book snapshot
+func bookLevels(n int) []Decimal {
+	levels := make([]Decimal, n)
+	price := MustParse("27350.5")
+	tick := MustParse("0.5")
+	for i := range levels {
+		levels[i] = price
+		price = price.Add(tick)
+		if i%7 == 0 {
+			price = price.Add(tick)
+		}
+	}
+	return levels
+}
+
+func benchmarkAppendSlice(b *testing.B, enc SliceEncoding) {
+	levels := bookLevels(1000)
+	var buf []byte
+
+	for i := 0; i < b.N; i++ {
+		buf, _ = AppendSlice(buf[:0], levels, enc)
+	}
+	b.ReportMetric(float64(len(buf))/float64(len(levels)), "bytes/value")
+}
+func BenchmarkAppendSlicePlain(b *testing.B) {
+	benchmarkAppendSlice(b, SlicePlain)
+}
+func BenchmarkAppendSliceDeltaAsc(b *testing.B) {
+	benchmarkAppendSlice(b, SliceDeltaAsc)
+}
+func BenchmarkAppendSliceDeltaOfDelta(b *testing.B) {
+	benchmarkAppendSlice(b, SliceDeltaOfDelta)
+}
+func BenchmarkMarshalBinarySlice(b *testing.B) {
+	levels := bookLevels(1000)
+	var buf []byte
+
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, f := range levels {
+			data, _ := f.MarshalBinary()
+			buf = append(buf, data...)
+		}
+	}
+	b.ReportMetric(float64(len(buf))/float64(len(levels)), "bytes/value")
+}
+func BenchmarkDecodeSliceDeltaAsc(b *testing.B) {
+	data, _ := AppendSlice(nil, bookLevels(1000), SliceDeltaAsc)
+	var levels []Decimal
+
+	for i := 0; i < b.N; i++ {
+		levels, _, _ = DecodeSlice(data, levels[:0])
+	}
+}
+
+// randomWalkPrices returns n prices that move by up to tick at a time
+func randomWalkPrices(n int, tick Decimal) []Decimal {
+	rnd := rand.New(rand.NewSource(1))
+	prices := make([]Decimal, n)
+	price := MustParse("27350.5")
+	for i := range prices {
+		prices[i] = price
+		switch rnd.Intn(3) {
+		case 0:
+			price = price.Add(tick)
+		case 1:
+			price = price.Sub(tick)
+		}
+	}
+	return prices
+}
+
+func benchmarkTickEncoder(b *testing.B, tick Decimal) {
+	prices := randomWalkPrices(100000, tick)
+	var varint bytes.Buffer
+	for _, f := range prices {
+		_ = f.WriteTo(&varint)
+	}
+
+	b.ResetTimer()
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		e := NewTickEncoder(0)
+		for _, f := range prices {
+			e.Encode(f)
+		}
+		data = e.Bytes()
+	}
+	b.ReportMetric(float64(len(data))/float64(len(prices)), "bytes/value")
+	b.ReportMetric(float64(varint.Len())/float64(len(data)), "ratio")
+}
+func BenchmarkTickEncoderCents(b *testing.B) {
+	benchmarkTickEncoder(b, MustParse("0.01"))
+}
+func BenchmarkTickEncoderSatoshis(b *testing.B) {
+	benchmarkTickEncoder(b, SmallestUnit)
+}
+func BenchmarkTickDecoder(b *testing.B) {
+	e := NewTickEncoder(0)
+	for _, f := range randomWalkPrices(100000, MustParse("0.01")) {
+		e.Encode(f)
+	}
+	data := e.Bytes()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d := NewTickDecoder(data)
+		for d.Next() {
+			parsed = d.Value()
+		}
+	}
+}
+func BenchmarkQuantizeToDecimal(b *testing.B) {
+	f := MustParse("101.37")
+	tick := MustParse("0.05")
+
+	for i := 0; i < b.N; i++ {
+		parsed = f.QuantizeTo(tick, RoundHalfEven)
+	}
+}
+func BenchmarkQuantizeShopspringDecimal(b *testing.B) {
+	f := decimal.RequireFromString("101.37")
+	tick := decimal.RequireFromString("0.05")
+
+	var result decimal.Decimal
+	for i := 0; i < b.N; i++ {
+		result = f.Div(tick).RoundBank(0).Mul(tick)
+	}
+	_ = result
+}
+func BenchmarkMulDivDecimal(b *testing.B) {
+	qty := MustParse("1234.5678")
+	share := MustParse("17")
+	total := MustParse("301")
+
+	for i := 0; i < b.N; i++ {
+		parsed, _ = qty.MulDiv(share, total, RoundHalfEven)
+	}
+}
+func BenchmarkMulThenDivDecimal(b *testing.B) {
+	qty := MustParse("1234.5678")
+	share := MustParse("17")
+	total := MustParse("301")
+
+	for i := 0; i < b.N; i++ {
+		parsed = qty.Mul(share).Div(total)
+	}
+}
+func BenchmarkAllocateInto(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	weights := make([]Decimal, 100)
+	for i := range weights {
+		weights[i] = Decimal{fp: rnd.Uint64() % scale * 1000}
+	}
+	total := MustParse("12345.678")
+	lot := MustParse("0.001")
+	dst := make([]Decimal, 0, len(weights))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst, _ = AllocateInto(dst[:0], total, weights, lot, RemainderLargest)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"math"
+	"math/big"
+	"math/rand"
 	"testing"
 
 	. "github.com/geseq/udecimal"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,6 +72,17 @@ func TestNew(t *testing.T) {
 
 	f = New(123456789012, -9)
 	assert.Equal(t, "123.45678901", f.StringN(8))
+
+	assert.Equal(t, "99999999999", New(99999999999, 0).String())
+	assert.Equal(t, "90000000000", New(9, 10).String())
+	assert.Equal(t, "0", New(0, 100).String())
+	assert.Equal(t, "0", New(123, -100).String())
+	assert.Equal(t, "0", New(123, math.MinInt32).String())
+	assert.Equal(t, "0.00000001", New(18446744073709551615, -27).String())
+	assert.Panics(t, func() { New(100000000000, 0) })
+	assert.Panics(t, func() { New(1, 11) })
+	assert.Panics(t, func() { New(1, 20) })
+	assert.Panics(t, func() { New(18446744073709551615, 1) })
 }
 
 func TestParse(t *testing.T) {
@@ -110,6 +124,36 @@ func TestNewI(t *testing.T) {
 
 	f = NewI(123456789012, 9)
 	assert.Equal(t, "123.45678901", f.StringN(8))
+
+	assert.Equal(t, Max, NewI(9999999999999999999, 8))
+	assert.Equal(t, "0", NewI(9999999999999999999, 28).String())
+	assert.Equal(t, "0.00000009", NewI(9999999999999999999, 26).String())
+	assert.Panics(t, func() { NewI(10000000000000000000, 8) })
+	assert.Panics(t, func() { NewI(100000000000, 0) })
+	assert.Panics(t, func() { NewI(1000000000000, 1) })
+}
+
+func TestUnits(t *testing.T) {
+	assert.Equal(t, MustParse("1.5"), FromUnits(150000000))
+	assert.Equal(t, Max, FromUnits(9999999999999999999))
+	assert.Equal(t, SmallestUnit, FromUnits(1))
+	assert.Panics(t, func() { FromUnits(10000000000000000000) })
+
+	assert.Equal(t, uint64(150000000), MustParse("1.5").Units())
+	assert.Equal(t, uint64(9999999999999999999), Max.Units())
+
+	f, err := FromIntFrac(12, 50000000)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("12.5"), f)
+
+	f, err = FromIntFrac(99999999999, 99999999)
+	assert.NoError(t, err)
+	assert.Equal(t, Max, f)
+
+	_, err = FromIntFrac(100000000000, 0)
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = FromIntFrac(1, 100000000)
+	assert.ErrorIs(t, err, ErrOverflow)
 }
 
 func TestMaxValue(t *testing.T) {
@@ -268,6 +312,139 @@ func TestMulDiv(t *testing.T) {
 
 }
 
+func TestDivExact(t *testing.T) {
+	f0 := MustParse("99999999999.99999999")
+	f1 := MustParse("3")
+	assert.Equal(t, "33333333333.33333333", f0.Div(f1).String())
+
+	f0 = MustParse("99999999999.99999999")
+	f1 = MustParse("1")
+	assert.Equal(t, "99999999999.99999999", f0.Div(f1).String())
+
+	f0 = MustParse("12345678901.23456789")
+	f1 = MustParse("0.00000010")
+	assert.Panics(t, func() { f0.Div(f1) })
+
+	f0 = MustParse("1")
+	f1 = MustParse("0.00000001")
+	assert.Equal(t, "100000000", f0.Div(f1).String())
+
+	f0 = MustParse("0.00000001")
+	f1 = MustParse("2")
+	assert.Equal(t, "0.00000001", f0.Div(f1).String())
+
+	f0 = MustParse("0.00000001")
+	f1 = MustParse("3")
+	assert.Equal(t, "0", f0.Div(f1).String())
+
+	assert.Equal(t, "0", Zero.Div(f1).String())
+}
+
+func TestDivByZero(t *testing.T) {
+	assert.PanicsWithValue(t, "decimal division by zero", func() { MustParse("1").Div(Zero) })
+	assert.PanicsWithValue(t, "decimal division by zero", func() { Zero.Div(Zero) })
+}
+
+func TestDivShopspring(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	maxValue := decimal.RequireFromString("99999999999.99999999")
+
+	for i := 0; i < 100000; i++ {
+		a := randomUnits(rnd)
+		b := randomUnits(rnd)
+		if b.Sign() == 0 {
+			continue
+		}
+
+		f0 := MustParse(a.String())
+		f1 := MustParse(b.String())
+
+		expected := a.DivRound(b, 8)
+		if expected.GreaterThan(maxValue) {
+			assert.Panics(t, func() { f0.Div(f1) }, "%s / %s", a, b)
+			continue
+		}
+		assert.Equal(t, expected.String(), f0.Div(f1).String(), "%s / %s", a, b)
+	}
+}
+
+func TestMulExact(t *testing.T) {
+	assert.Equal(t, "99999999999.99999999", Max.Mul(MustParse("1")).String())
+	assert.Equal(t, "0.99999998", MustParse("0.99999999").Mul(MustParse("0.99999999")).String())
+	assert.Equal(t, "0", SmallestUnit.Mul(SmallestUnit).String())
+	assert.Equal(t, "99999999980", MustParse("9999999998").Mul(MustParse("10")).String())
+	assert.Equal(t, "99999999999.9999999", MustParse("9999999999.99999999").Mul(MustParse("10")).String())
+
+	// products just above MAX
+	assert.PanicsWithValue(t, "decimal overflow", func() { MustParse("99999999999").Mul(MustParse("1.00000001")) })
+	assert.PanicsWithValue(t, "decimal overflow", func() { Max.Mul(MustParse("1.00000001")) })
+	assert.PanicsWithValue(t, "decimal overflow", func() { MustParse("316227.76601684").Mul(MustParse("316227.76601684")) })
+	assert.PanicsWithValue(t, "decimal overflow", func() { Max.Mul(Max) })
+}
+
+func TestMulRound(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected []string // in the order of roundModes
+	}{
+		{"1.00000001", "0.5", []string{"0.50000001", "0.5", "0.5", "0.5", "0.50000001", "0.50000001", "0.5"}},
+		{"1.00000003", "0.5", []string{"0.50000002", "0.50000001", "0.50000002", "0.50000001", "0.50000002", "0.50000002", "0.50000001"}},
+		{"0.00000001", "0.5", []string{"0.00000001", "0", "0", "0", "0.00000001", "0.00000001", "0"}},
+		{"0.00000001", "0.50000001", []string{"0.00000001", "0.00000001", "0.00000001", "0", "0.00000001", "0.00000001", "0"}},
+		{"12.5", "0.0006", []string{"0.0075", "0.0075", "0.0075", "0.0075", "0.0075", "0.0075", "0.0075"}},
+	}
+
+	for _, tt := range tests {
+		a, b := MustParse(tt.a), MustParse(tt.b)
+		for i, mode := range roundModes {
+			assert.Equal(t, tt.expected[i], a.MulRound(b, mode).String(), "%s * %s %v", tt.a, tt.b, mode)
+		}
+		assert.Equal(t, a.Mul(b), a.MulRound(b, RoundDown))
+	}
+
+	// the exact product is 99999999999.9999999999999999999, which only fits when rounded down
+	a, b := MustParse("9.99999999"), MustParse("10000000010.00000001")
+	assert.Equal(t, Max, a.MulRound(b, RoundDown))
+	assert.Equal(t, Max, a.MulRound(b, RoundFloor))
+	assert.PanicsWithValue(t, "decimal overflow", func() { a.MulRound(b, RoundHalfEven) })
+	assert.PanicsWithValue(t, "decimal overflow", func() { a.MulRound(b, RoundCeiling) })
+	assert.Panics(t, func() { Max.MulRound(MustParse("1.00000001"), RoundDown) })
+}
+
+func TestMulShopspring(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	maxValue := decimal.RequireFromString("99999999999.99999999")
+
+	for i := 0; i < 20000; i++ {
+		a := randomUnits(rnd)
+		b := randomUnits(rnd)
+		f0 := MustParse(a.String())
+		f1 := MustParse(b.String())
+
+		exact := a.Mul(b)
+		halfDown := exact.Round(8)
+		if exact.Sub(exact.RoundDown(8)).Equal(decimal.New(5, -9)) {
+			halfDown = exact.RoundDown(8)
+		}
+		expected := []decimal.Decimal{exact.Round(8), halfDown, exact.RoundBank(8), exact.RoundDown(8), exact.RoundUp(8), exact.RoundCeil(8), exact.RoundFloor(8)}
+		for j, mode := range roundModes {
+			if expected[j].GreaterThan(maxValue) {
+				assert.Panics(t, func() { f0.MulRound(f1, mode) }, "%s * %s %v", a, b, mode)
+				continue
+			}
+			assert.Equal(t, expected[j].String(), f0.MulRound(f1, mode).String(), "%s * %s %v", a, b, mode)
+		}
+	}
+}
+
+// randomUnits returns a shopspring decimal with 8 decimal places spread evenly over the magnitudes
+// of the representable range
+func randomUnits(rnd *rand.Rand) decimal.Decimal {
+	digits := rnd.Intn(19) + 1
+	units := new(big.Int).Rand(rnd, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+	return decimal.NewFromBigInt(units, -8)
+}
+
 func TestNegatives(t *testing.T) {
 	assert.Panics(t, func() { MustParse("-1") })
 
@@ -445,3 +622,96 @@ func TestJSON(t *testing.T) {
 		t.Error("don't match", j.F, f)
 	}
 }
+
+func TestJSONQuoted(t *testing.T) {
+	var j JStruct
+	assert.NoError(t, json.Unmarshal([]byte(`{"f":"1.5"}`), &j))
+	assert.Equal(t, MustParse("1.5"), j.F)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"f":2.25}`), &j))
+	assert.Equal(t, MustParse("2.25"), j.F)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"f":null}`), &j))
+	assert.Equal(t, MustParse("2.25"), j.F)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"f":""}`), &j))
+	assert.Error(t, json.Unmarshal([]byte(`{"f":"abc"}`), &j))
+}
+
+func TestText(t *testing.T) {
+	b, err := MustParse("1.50").MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", string(b))
+
+	var f Decimal
+	assert.NoError(t, f.UnmarshalText([]byte("123.456")))
+	assert.Equal(t, MustParse("123.456"), f)
+	assert.Error(t, f.UnmarshalText([]byte("1.2.3")))
+
+	m := map[Decimal]int{MustParse("1.5"): 1, MustParse("100"): 2}
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1.5":1,"100":2}`, string(data))
+
+	var m0 map[Decimal]int
+	assert.NoError(t, json.Unmarshal(data, &m0))
+	assert.Equal(t, m, m0)
+}
+
+type SStruct struct {
+	F StringDecimal  `json:"f"`
+	P *StringDecimal `json:"p"`
+}
+
+func TestStringDecimal(t *testing.T) {
+	s := SStruct{F: StringDecimal{MustParse("1.50")}}
+	data, err := json.Marshal(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"f":"1.5","p":null}`, string(data))
+
+	var s0 SStruct
+	assert.NoError(t, json.Unmarshal(data, &s0))
+	assert.Equal(t, s, s0)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"f":0.00000001,"p":"99999999999.99999999"}`), &s0))
+	assert.Equal(t, SmallestUnit, s0.F.Decimal)
+	assert.Equal(t, Max, s0.P.Decimal)
+
+	data, err = json.Marshal(StringDecimal{Zero})
+	assert.NoError(t, err)
+	assert.Equal(t, `"0"`, string(data))
+}
+
+func TestAppendString(t *testing.T) {
+	buf := []byte("price=")
+
+	assert.Equal(t, "price=1234.5678", string(MustParse("1234.5678").AppendString(buf)))
+	assert.Equal(t, "price=0", string(Zero.AppendString(buf)))
+	assert.Equal(t, "price=100", string(MustParse("100").AppendString(buf)))
+	assert.Equal(t, "price=0.00000001", string(SmallestUnit.AppendString(buf)))
+	assert.Equal(t, "price=99999999999.99999999", string(Max.AppendString(buf)))
+
+	assert.Equal(t, "price=1234.56", string(MustParse("1234.5678").AppendStringN(buf, 2)))
+	assert.Equal(t, "price=1234", string(MustParse("1234.5678").AppendStringN(buf, 0)))
+	assert.Equal(t, "price=1234", string(MustParse("1234.5678").AppendStringN(buf, -1)))
+	assert.Equal(t, "price=0.00", string(Zero.AppendStringN(buf, 2)))
+	assert.Equal(t, "price=1.1000000000", string(MustParse("1.1").AppendStringN(buf, 10)))
+
+	b, err := MustParse("1.5").AppendText(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "price=1.5", string(b))
+
+	assert.Equal(t, "1.10000000000", MustParse("1.1").StringN(11))
+}
+
+func TestAppendStringAllocs(t *testing.T) {
+	f := MustParse("123456789.12345")
+	buf := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.AppendString(buf[:0])
+		buf = f.AppendStringN(buf[:0], 2)
+		buf, _ = f.AppendText(buf[:0])
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,305 @@
+package udecimal
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Format implements the fmt.Formatter interface. The supported verbs are:
+//
+//	%v, %s  the String form, or the value with the given number of decimal places as for %f
+//	%d      the value rounded to an integer
+//	%f, %F  the value with the given number of decimal places, 6 by default
+//	%e, %E  scientific notation with the given number of decimal places, 6 by default
+//	%g, %G  %e for large exponents, %f otherwise, with the given number of significant digits or as many as
+//	        necessary by default
+//	%q      the %v form in double quotes, or back quotes with the '#' flag
+//
+// Values are rounded half to even, which matches strconv.FormatFloat for values that are exactly
+// representable as a float64. Width and the '+', ' ', '-' and '0' flags behave as they do for floats.
+func (f Decimal) Format(s fmt.State, verb rune) {
+	var buffer [24]byte
+	formatDecimal(s, verb, "udecimal.Decimal", false, f.AppendString(buffer[:0]))
+}
+
+// digits is a decimal number 0.d[0]d[1]...d[nd-1] * 10^dp without leading or trailing zeros, in the same
+// form that strconv uses to format floats. Zero has nd and dp both 0.
+type digits struct {
+	d  [48]byte
+	nd int
+	dp int
+}
+
+// set loads the formatted unsigned number b, such as "123.45", into ds
+func (ds *digits) set(b []byte) {
+	ds.nd, ds.dp = 0, 0
+	point := false
+	for _, c := range b {
+		if c == '.' {
+			point = true
+			continue
+		}
+		if !point {
+			ds.dp++
+		}
+		if c == '0' && ds.nd == 0 {
+			ds.dp--
+			continue
+		}
+		ds.d[ds.nd] = c
+		ds.nd++
+	}
+	ds.trim()
+}
+
+// trim drops trailing zeros
+func (ds *digits) trim() {
+	for ds.nd > 0 && ds.d[ds.nd-1] == '0' {
+		ds.nd--
+	}
+	if ds.nd == 0 {
+		ds.dp = 0
+	}
+}
+
+// round rounds ds half to even to nd significant digits
+func (ds *digits) round(nd int) {
+	if nd < 0 {
+		ds.nd, ds.dp = 0, 0
+		return
+	}
+	if nd >= ds.nd {
+		return
+	}
+	c := ds.d[nd]
+	up := c > '5' || c == '5' && (nd+1 < ds.nd || nd > 0 && (ds.d[nd-1]-'0')%2 == 1)
+	ds.nd = nd
+	if !up {
+		ds.trim()
+		return
+	}
+	for ds.nd > 0 && ds.d[ds.nd-1] == '9' {
+		ds.nd--
+	}
+	if ds.nd == 0 {
+		ds.d[0] = '1'
+		ds.nd = 1
+		ds.dp++
+		return
+	}
+	ds.d[ds.nd-1]++
+}
+
+// appendF appends ds with prec decimal places, as %f
+func (ds *digits) appendF(dst []byte, prec int) []byte {
+	if ds.dp > 0 {
+		m := ds.dp
+		if m > ds.nd {
+			m = ds.nd
+		}
+		dst = append(dst, ds.d[:m]...)
+		for ; m < ds.dp; m++ {
+			dst = append(dst, '0')
+		}
+	} else {
+		dst = append(dst, '0')
+	}
+	if prec > 0 {
+		dst = append(dst, '.')
+		for i := 0; i < prec; i++ {
+			c := byte('0')
+			if j := ds.dp + i; j >= 0 && j < ds.nd {
+				c = ds.d[j]
+			}
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// appendE appends ds in scientific notation with prec decimal places, as %e
+func (ds *digits) appendE(dst []byte, prec int, e byte) []byte {
+	c := byte('0')
+	if ds.nd > 0 {
+		c = ds.d[0]
+	}
+	dst = append(dst, c)
+	if prec > 0 {
+		dst = append(dst, '.')
+		for i := 1; i <= prec; i++ {
+			c = '0'
+			if i < ds.nd {
+				c = ds.d[i]
+			}
+			dst = append(dst, c)
+		}
+	}
+	dst = append(dst, e)
+
+	exp := ds.dp - 1
+	if ds.nd == 0 {
+		exp = 0
+	}
+	if exp < 0 {
+		dst = append(dst, '-')
+		exp = -exp
+	} else {
+		dst = append(dst, '+')
+	}
+	if exp < 10 {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendInt(dst, int64(exp), 10)
+}
+
+// appendG appends ds with prec significant digits, or as many as required if prec is negative, as %g
+func (ds *digits) appendG(dst []byte, prec int, e byte) []byte {
+	shortest := prec < 0
+	if shortest {
+		prec = ds.nd
+	} else {
+		if prec == 0 {
+			prec = 1
+		}
+		ds.round(prec)
+	}
+
+	eprec := prec
+	if eprec > ds.nd && ds.nd >= ds.dp {
+		eprec = ds.nd
+	}
+	if shortest {
+		eprec = 6
+	}
+	if exp := ds.dp - 1; exp < -4 || exp >= eprec {
+		if prec > ds.nd {
+			prec = ds.nd
+		}
+		return ds.appendE(dst, prec-1, e)
+	}
+	if prec > ds.dp {
+		prec = ds.nd
+	}
+	if prec -= ds.dp; prec < 0 {
+		prec = 0
+	}
+	return ds.appendF(dst, prec)
+}
+
+// formatDecimal implements fmt.Formatter for the decimal types. b is the unsigned String form of the value
+// and neg its sign.
+func formatDecimal(s fmt.State, verb rune, typeName string, neg bool, b []byte) {
+	var ds digits
+	ds.set(b)
+
+	prec, hasPrec := s.Precision()
+	var buffer [64]byte
+	num := buffer[:0]
+	switch verb {
+	case 'v', 's', 'q':
+		if !hasPrec {
+			prec = ds.nd - ds.dp
+		}
+		ds.round(ds.dp + prec)
+		num = ds.appendF(num, prec)
+	case 'd':
+		ds.round(ds.dp)
+		num = ds.appendF(num, 0)
+	case 'f', 'F':
+		if !hasPrec {
+			prec = 6
+		}
+		ds.round(ds.dp + prec)
+		num = ds.appendF(num, prec)
+	case 'e', 'E':
+		if !hasPrec {
+			prec = 6
+		}
+		ds.round(prec + 1)
+		num = ds.appendE(num, prec, byte(verb))
+	case 'g', 'G':
+		if !hasPrec {
+			prec = -1
+		}
+		num = ds.appendG(num, prec, byte(verb)+'e'-'g')
+	default:
+		out := append(buffer[:0], "%!"...)
+		out = append(out, string(verb)...)
+		out = append(out, '(')
+		out = append(out, typeName...)
+		out = append(out, '=')
+		if neg {
+			out = append(out, '-')
+		}
+		out = append(out, b...)
+		out = append(out, ')')
+		s.Write(out)
+		return
+	}
+
+	var sign byte
+	switch {
+	case neg:
+		sign = '-'
+	case s.Flag('+') && verb != 'q':
+		sign = '+'
+	case s.Flag(' ') && verb != 'q':
+		sign = ' '
+	}
+
+	var out []byte
+	if verb == 'q' {
+		quote := byte('"')
+		if s.Flag('#') {
+			quote = '`'
+		}
+		out = append(out, quote)
+		if sign != 0 {
+			out = append(out, sign)
+		}
+		out = append(append(out, num...), quote)
+		writePadded(s, out)
+		return
+	}
+
+	width, _ := s.Width()
+	n := len(num)
+	if sign != 0 {
+		n++
+	}
+	if s.Flag('0') && !s.Flag('-') && width > n {
+		out = make([]byte, 0, width)
+		if sign != 0 {
+			out = append(out, sign)
+		}
+		for ; n < width; n++ {
+			out = append(out, '0')
+		}
+		writePadded(s, append(out, num...))
+		return
+	}
+	if sign != 0 {
+		out = append(out, sign)
+	}
+	writePadded(s, append(out, num...))
+}
+
+// writePadded writes b to s padded with spaces to the width of s
+func writePadded(s fmt.State, b []byte) {
+	width, _ := s.Width()
+	if len(b) >= width {
+		s.Write(b)
+		return
+	}
+	out := make([]byte, 0, width)
+	if s.Flag('-') {
+		out = append(out, b...)
+	}
+	for n := len(b); n < width; n++ {
+		out = append(out, ' ')
+	}
+	if !s.Flag('-') {
+		out = append(out, b...)
+	}
+	s.Write(out)
+}
@@ -0,0 +1,126 @@
+package udecimal_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		input    string
+		expected string
+	}{
+		{"%v", "1.5", "1.5"},
+		{"%s", "1.5", "1.5"},
+		{"%v", "0", "0"},
+		{"%.2v", "1.005", "1.00"},
+		{"%.2s", "1.015", "1.02"},
+		{"%d", "2.5", "2"},
+		{"%d", "3.5", "4"},
+		{"%d", "3.50000001", "4"},
+		{"%f", "1.5", "1.500000"},
+		{"%.2f", "1.005", "1.00"},
+		{"%.2f", "1.015", "1.02"},
+		{"%.2f", "1.00500001", "1.01"},
+		{"%.0f", "0.5", "0"},
+		{"%.0f", "99.5", "100"},
+		{"%.10f", "0.00000001", "0.0000000100"},
+		{"%.2f", "0.004", "0.00"},
+		{"%F", "12.25", "12.250000"},
+		{"%e", "123.456", "1.234560e+02"},
+		{"%.2E", "0.00012345", "1.23E-04"},
+		{"%.0e", "0", "0e+00"},
+		{"%e", "99999999999.99999999", "1.000000e+11"},
+		{"%g", "123.456", "123.456"},
+		{"%g", "0.00001", "1e-05"},
+		{"%g", "1234567", "1.234567e+06"},
+		{"%.3g", "1234.5", "1.23e+03"},
+		{"%.3g", "0.0012345", "0.00123"},
+		{"%G", "0.00000001", "1E-08"},
+		{"%q", "1.5", `"1.5"`},
+		{"%#q", "1.5", "`1.5`"},
+		{"%8.2f", "1.5", "    1.50"},
+		{"%-8.2f|", "1.5", "1.50    |"},
+		{"%08.2f", "1.5", "00001.50"},
+		{"%-08.2f|", "1.5", "1.50    |"},
+		{"%+.2f", "1.5", "+1.50"},
+		{"%+08.2f", "1.5", "+0001.50"},
+		{"% .1f", "1.5", " 1.5"},
+		{"%6q", "1.5", ` "1.5"`},
+		{"%6v", "1.5", "   1.5"},
+		{"%x", "1.5", "%!x(udecimal.Decimal=1.5)"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, fmt.Sprintf(tt.format, MustParse(tt.input)), "%s %s", tt.format, tt.input)
+	}
+
+	assert.Equal(t, "{1.5 2}", fmt.Sprintf("%v", struct{ A, B Decimal }{MustParse("1.5"), MustParse("2")}))
+	d := MustParse("1.25")
+	assert.Equal(t, "1.2", fmt.Sprintf("%.1f", &d))
+}
+
+func TestFormatSigned(t *testing.T) {
+	assert.Equal(t, "-1.5", fmt.Sprintf("%v", MustParseSigned("-1.5")))
+	assert.Equal(t, "-0001.50", fmt.Sprintf("%08.2f", MustParseSigned("-1.5")))
+	assert.Equal(t, "-0.00", fmt.Sprintf("%.2f", MustParseSigned("-0.001")))
+	assert.Equal(t, `"-1.5"`, fmt.Sprintf("%q", MustParseSigned("-1.5")))
+	assert.Equal(t, "-1.5e+00", fmt.Sprintf("%.1e", MustParseSigned("-1.5")))
+	assert.Equal(t, "%!x(udecimal.SDecimal=-1.5)", fmt.Sprintf("%x", MustParseSigned("-1.5")))
+}
+
+func TestFormatOtherTypes(t *testing.T) {
+	assert.Equal(t, "123456789012345678901234.50", fmt.Sprintf("%.2f", MustParseDecimal128("123456789012345678901234.5")))
+	assert.Equal(t, "1.2345678901e+23", fmt.Sprintf("%.10e", MustParseDecimal128("123456789012345678901234.5")))
+	assert.Equal(t, "  100.25", fmt.Sprintf("%8v", MustParseDecimal2("100.25")))
+	assert.Equal(t, "1.08", fmt.Sprintf("%.2f", MustParseDecimal4("1.0825")))
+	assert.Equal(t, "%!x(udecimal.Decimal6=0.5)", fmt.Sprintf("%x", MustParseDecimal6("0.5")))
+}
+
+// TestFormatStrconv compares formatting against float64 formatting for values that are exactly
+// representable in both, n/256 having at most 8 decimal places.
+func TestFormatStrconv(t *testing.T) {
+	formats := []string{
+		"%f", "%.0f", "%.1f", "%.2f", "%.3f", "%.5f", "%.7f", "%.12f",
+		"%e", "%.0e", "%.2e", "%.5E", "%.12e",
+		"%.1g", "%.3g", "%.6g", "%.10G", "%.20g",
+		"%12.3f", "%-12.3f|", "%012.3f", "%+.4f", "% .2e", "%+015.3e",
+	}
+	shortest := []string{"%g", "%G", "%12g"}
+
+	rnd := rand.New(rand.NewSource(11))
+	for i := 0; i < 5000; i++ {
+		var n uint64
+		switch i % 3 {
+		case 0:
+			n = uint64(rnd.Int63n(90000000000 * 256))
+		case 1:
+			n = uint64(rnd.Int63n(1000 * 256))
+		default:
+			n = uint64(rnd.Int63n(256))
+		}
+		f := float64(n) / 256
+		d := MustParse(strconv.FormatFloat(f, 'f', 8, 64))
+		s := MustParseSigned(d.String()).Neg()
+
+		for _, format := range formats {
+			assert.Equal(t, fmt.Sprintf(format, f), fmt.Sprintf(format, d), "%s %v", format, d)
+			if n != 0 {
+				assert.Equal(t, fmt.Sprintf(format, -f), fmt.Sprintf(format, s), "%s %v", format, s)
+			}
+		}
+		if n < 1<<20 {
+			// below 2^12 the exact value has at most 12 significant digits, which the shortest float
+			// representation preserves
+			for _, format := range shortest {
+				assert.Equal(t, fmt.Sprintf(format, f), fmt.Sprintf(format, d), "%s %v", format, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,370 @@
+//go:build ignore
+// +build ignore
+
+// gen_scaled generates the fixed scale decimal types in scaled_gen.go. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"text/template"
+)
+
+// scales lists the generated numbers of decimal places. Decimal itself provides 8 places.
+var scales = []int{2, 4, 6}
+
+type scaleInfo struct {
+	Places  int
+	Type    string
+	Unit    string
+	Max     string
+	Targets []target
+}
+
+type target struct {
+	Places int
+	Type   string
+}
+
+func typeName(places int) string {
+	if places == 8 {
+		return "Decimal"
+	}
+	return "Decimal" + itoa(places)
+}
+
+func itoa(i int) string {
+	return string(rune('0' + i))
+}
+
+func targets(places int) []target {
+	var t []target
+	for _, p := range append(scales, 8) {
+		if p != places {
+			t = append(t, target{Places: p, Type: typeName(p)})
+		}
+	}
+	return t
+}
+
+func maxString(places int) string {
+	const digits = "9999999999999999999"
+	return digits[:19-places] + "." + digits[:places]
+}
+
+func unitString(places int) string {
+	s := "1"
+	for i := 0; i < places; i++ {
+		s += "0"
+	}
+	return s
+}
+
+func main() {
+	var types []scaleInfo
+	for _, p := range scales {
+		types = append(types, scaleInfo{Places: p, Type: typeName(p), Unit: unitString(p), Max: maxString(p), Targets: targets(p)})
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, struct {
+		Types   []scaleInfo
+		Targets []target
+	}{types, targets(8)})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("scaled_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var tmpl = template.Must(template.New("scaled").Parse(`// Code generated by gen_scaled.go; DO NOT EDIT.
+
+package udecimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+{{range $t := .Types}}
+// {{.Type}} is a decimal with a fixed {{.Places}} decimal places. The maximum permitted value is {{.Max}}.
+type {{.Type}} struct {
+	fp uint64
+}
+
+const places{{.Places}} = {{.Places}}
+const unit{{.Places}} = uint64({{.Unit}})
+
+var Zero{{.Places}} = {{.Type}}{fp: 0}
+
+// Max{{.Places}} is the largest representable {{.Type}}
+var Max{{.Places}} = {{.Type}}{fp: maxFP}
+
+// Parse{{.Type}} creates a {{.Type}} from a string, truncating fractional digits beyond {{.Places}} decimal places
+func Parse{{.Type}}(s string) ({{.Type}}, error) {
+	fp, err := parseUnits(s, places{{.Places}})
+	if err != nil {
+		return Zero{{.Places}}, err
+	}
+	return {{.Type}}{fp: fp}, nil
+}
+
+// MustParse{{.Type}} creates a new {{.Type}} from a string, and panics if the string could not be parsed
+func MustParse{{.Type}}(s string) {{.Type}} {
+	f, err := Parse{{.Type}}(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseStrict{{.Type}} creates a {{.Type}} from a string like ParseStrict, rejecting more than {{.Places}}
+// significant fractional digits
+func ParseStrict{{.Type}}(s string) ({{.Type}}, error) {
+	fp, err := parseStrictUnits(s, places{{.Places}})
+	if err != nil {
+		return Zero{{.Places}}, err
+	}
+	return {{.Type}}{fp: fp}, nil
+}
+
+func (f {{.Type}}) IsZero() bool {
+	return f.fp == 0
+}
+
+// Float converts the {{.Type}} to a float64
+func (f {{.Type}}) Float() float64 {
+	return float64(f.fp) / float64(unit{{.Places}})
+}
+
+// Add adds f0 to f producing a {{.Type}}.
+func (f {{.Type}}) Add(f0 {{.Type}}) {{.Type}} {
+	fp, err := addUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return {{.Type}}{fp: fp}
+}
+
+// Sub subtracts f0 from f producing a {{.Type}}.
+func (f {{.Type}}) Sub(f0 {{.Type}}) {{.Type}} {
+	fp, err := subUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return {{.Type}}{fp: fp}
+}
+
+// Mul multiplies f by f0 returning a {{.Type}}, truncating to {{.Places}} decimal places.
+func (f {{.Type}}) Mul(f0 {{.Type}}) {{.Type}} {
+	return f.MulRound(f0, RoundDown)
+}
+
+// MulRound multiplies f by f0 like Mul, rounding the exact product to {{.Places}} decimal places using the
+// specified rounding mode.
+func (f {{.Type}}) MulRound(f0 {{.Type}}, mode RoundMode) {{.Type}} {
+	fp, err := mulUnits(f.fp, f0.fp, unit{{.Places}}, mode)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return {{.Type}}{fp: fp}
+}
+
+// Div divides f by f0 returning a {{.Type}}, rounded half-up to {{.Places}} decimal places.
+// Div panics if f0 is zero or if the result overflows.
+func (f {{.Type}}) Div(f0 {{.Type}}) {{.Type}} {
+	fp, err := divUnits(f.fp, f0.fp, unit{{.Places}}, RoundHalfUp)
+	if err != nil {
+		if err == ErrDivisionByZero {
+			panic("decimal division by zero")
+		}
+		panic("decimal overflow")
+	}
+	return {{.Type}}{fp: fp}
+}
+
+// Round returns f rounded (half-up, away from zero) to n decimal places
+func (f {{.Type}}) Round(n int) {{.Type}} {
+	return f.RoundWith(n, RoundHalfUp)
+}
+
+// RoundWith returns f rounded to n decimal places using the specified rounding mode
+func (f {{.Type}}) RoundWith(n int, mode RoundMode) {{.Type}} {
+	fp, ok := roundUnits(f.fp, places{{.Places}}, n, mode)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return {{.Type}}{fp: fp}
+}
+
+// Equal returns true if the f == f0.
+func (f {{.Type}}) Equal(f0 {{.Type}}) bool {
+	return f.fp == f0.fp
+}
+
+// GreaterThan returns true if the f > f0.
+func (f {{.Type}}) GreaterThan(f0 {{.Type}}) bool {
+	return f.fp > f0.fp
+}
+
+// GreaterThanOrEqual returns true if the f >= f0.
+func (f {{.Type}}) GreaterThanOrEqual(f0 {{.Type}}) bool {
+	return f.fp >= f0.fp
+}
+
+// LessThan returns true if the f < f0.
+func (f {{.Type}}) LessThan(f0 {{.Type}}) bool {
+	return f.fp < f0.fp
+}
+
+// LessThanOrEqual returns true if the f <= f0.
+func (f {{.Type}}) LessThanOrEqual(f0 {{.Type}}) bool {
+	return f.fp <= f0.fp
+}
+
+// Cmp compares two {{.Type}}. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1.
+func (f {{.Type}}) Cmp(f0 {{.Type}}) int {
+	if f.fp == f0.fp {
+		return 0
+	}
+	if f.fp < f0.fp {
+		return -1
+	}
+	return 1
+}
+
+// String converts a {{.Type}} to a string, dropping trailing zeros
+func (f {{.Type}}) String() string {
+	var buffer [24]byte
+	return string(f.AppendString(buffer[:0]))
+}
+
+// StringN converts a {{.Type}} to a String with a specified number of decimal places, truncating as required
+func (f {{.Type}}) StringN(decimals int) string {
+	var buffer [32]byte
+	return string(f.AppendStringN(buffer[:0], decimals))
+}
+
+// AppendString appends the string form of f, as returned by String, to dst and returns the extended buffer
+func (f {{.Type}}) AppendString(dst []byte) []byte {
+	return appendUnits(dst, f.fp, places{{.Places}})
+}
+
+// AppendStringN appends f with the specified number of decimal places, as returned by StringN, to dst and
+// returns the extended buffer
+func (f {{.Type}}) AppendStringN(dst []byte, decimals int) []byte {
+	return appendUnitsN(dst, f.fp, places{{.Places}}, decimals)
+}
+
+// AppendText implements the encoding.TextAppender interface, appending the string form of f to b
+func (f {{.Type}}) AppendText(b []byte) ([]byte, error) {
+	return f.AppendString(b), nil
+}
+
+// Format implements the fmt.Formatter interface. See Decimal.Format for the supported verbs.
+func (f {{.Type}}) Format(s fmt.State, verb rune) {
+	var buffer [24]byte
+	formatDecimal(s, verb, "udecimal.{{.Type}}", false, f.AppendString(buffer[:0]))
+}
+
+// Int return the integer portion of the {{.Type}}
+func (f {{.Type}}) Int() uint64 {
+	return f.fp / unit{{.Places}}
+}
+{{range .Targets}}
+// To{{.Type}} converts f to a {{.Type}}, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f {{$t.Type}}) To{{.Type}}(mode RoundMode) ({{.Type}}, error) {
+	fp, err := rescaleUnits(f.fp, places{{$t.Places}}, {{.Places}}, mode)
+	return {{.Type}}{fp: fp}, err
+}
+{{end}}
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (f *{{.Type}}) UnmarshalBinary(data []byte) error {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errFormat
+	}
+	f.fp = fp
+	return nil
+}
+
+// UnmarshalBinaryData Unmarshals data and returns n
+func (f *{{.Type}}) UnmarshalBinaryData(data []byte) (rem []byte, err error) {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return data, errFormat
+	}
+	f.fp = fp
+	return data[n:], nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f {{.Type}}) MarshalBinary() (data []byte, err error) {
+	var buffer [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buffer[:], f.fp)
+	return buffer[:n], nil
+}
+
+// WriteTo write the {{.Type}} to an io.Writer, returning the number of bytes written
+func (f {{.Type}}) WriteTo(w io.ByteWriter) error {
+	return writeUvarint(w, f.fp)
+}
+
+// Read{{.Type}}From reads a {{.Type}} from an io.Reader
+func Read{{.Type}}From(r io.ByteReader) ({{.Type}}, error) {
+	fp, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Zero{{.Places}}, err
+	}
+	return {{.Type}}{fp: fp}, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (f *{{.Type}}) UnmarshalText(text []byte) error {
+	decimal, err := Parse{{.Type}}(string(text))
+	*f = decimal
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the String form of f
+func (f {{.Type}}) MarshalText() ([]byte, error) {
+	return f.AppendString(nil), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both JSON numbers and strings are accepted.
+func (f *{{.Type}}) UnmarshalJSON(bytes []byte) error {
+	s := string(bytes)
+	if s == "null" {
+		return nil
+	}
+
+	decimal, err := Parse{{.Type}}(unquoteJSON(s))
+	*f = decimal
+	if err != nil {
+		return fmt.Errorf("Error decoding string '%s': %s", s, err)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f {{.Type}}) MarshalJSON() ([]byte, error) {
+	return f.AppendStringN(make([]byte, 0, 24), places{{.Places}}), nil
+}
+{{end}}{{range .Targets}}
+// To{{.Type}} converts f to a {{.Type}}, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal) To{{.Type}}(mode RoundMode) ({{.Type}}, error) {
+	fp, err := rescaleUnits(f.fp, nPlaces, {{.Places}}, mode)
+	return {{.Type}}{fp: fp}, err
+}
+{{end}}`))
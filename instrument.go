@@ -0,0 +1,150 @@
+package udecimal
+
+import "errors"
+
+var (
+	// ErrTickSize is the ValidationError.Err of a price that is not a multiple of the tick size
+	ErrTickSize = errors.New("not a multiple of the tick size")
+	// ErrLotSize is the ValidationError.Err of a quantity that is not a multiple of the lot size
+	ErrLotSize = errors.New("not a multiple of the lot size")
+	// ErrBelowMin is the ValidationError.Err of a value that is less than its minimum
+	ErrBelowMin = errors.New("below the minimum")
+	// ErrAboveMax is the ValidationError.Err of a value that is greater than its maximum
+	ErrAboveMax = errors.New("above the maximum")
+)
+
+// The ValidationError.Field values
+const (
+	FieldPrice    = "price"
+	FieldQty      = "quantity"
+	FieldNotional = "notional"
+)
+
+// ValidationError describes a price, quantity or notional that does not meet an Instrument's specification.
+// Use errors.Is with ErrTickSize, ErrLotSize, ErrBelowMin or ErrAboveMax to test for the reason.
+type ValidationError struct {
+	Field string  // FieldPrice, FieldQty or FieldNotional
+	Value Decimal // the rejected value
+	Limit Decimal // the tick size, lot size or bound that was not met
+	Err   error   // the reason
+}
+
+// Error returns a message such as "price 101.37 not a multiple of the tick size 0.05"
+func (e *ValidationError) Error() string {
+	return e.Field + " " + e.Value.String() + " " + e.Err.Error() + " " + e.Limit.String()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Instrument is the trading specification of an instrument. A zero tick size, lot size, maximum or minimum
+// notional is not checked.
+type Instrument struct {
+	TickSize    Decimal // prices must be a multiple of TickSize
+	LotSize     Decimal // quantities must be a multiple of LotSize
+	MinPrice    Decimal
+	MaxPrice    Decimal
+	MinQty      Decimal
+	MaxQty      Decimal
+	MinNotional Decimal // the minimum price * quantity of an order
+	Precision   int     // the number of decimal places shown by FormatPrice
+}
+
+// ValidatePrice returns a *ValidationError if price is not a multiple of the tick size or is outside the
+// price limits
+func (in *Instrument) ValidatePrice(price Decimal) error {
+	if !in.TickSize.IsZero() && !price.IsMultipleOf(in.TickSize) {
+		return &ValidationError{Field: FieldPrice, Value: price, Limit: in.TickSize, Err: ErrTickSize}
+	}
+	return checkLimits(FieldPrice, price, in.MinPrice, in.MaxPrice)
+}
+
+// ValidateQty returns a *ValidationError if qty is not a multiple of the lot size or is outside the
+// quantity limits
+func (in *Instrument) ValidateQty(qty Decimal) error {
+	if !in.LotSize.IsZero() && !qty.IsMultipleOf(in.LotSize) {
+		return &ValidationError{Field: FieldQty, Value: qty, Limit: in.LotSize, Err: ErrLotSize}
+	}
+	return checkLimits(FieldQty, qty, in.MinQty, in.MaxQty)
+}
+
+// ValidateOrder validates the price and quantity of an order, then checks that its notional, price * qty
+// truncated to 8 decimal places, is at least the minimum notional. It returns the first *ValidationError.
+func (in *Instrument) ValidateOrder(price, qty Decimal) error {
+	if err := in.ValidatePrice(price); err != nil {
+		return err
+	}
+	if err := in.ValidateQty(qty); err != nil {
+		return err
+	}
+	if in.MinNotional.IsZero() {
+		return nil
+	}
+	// a notional larger than MAX is above any minimum
+	notional, err := mulUnits(price.fp, qty.fp, scale, RoundDown)
+	if err == nil && notional < in.MinNotional.fp {
+		return &ValidationError{Field: FieldNotional, Value: Decimal{fp: notional}, Limit: in.MinNotional, Err: ErrBelowMin}
+	}
+	return nil
+}
+
+// NormalizePrice rounds price to a multiple of the tick size using mode and validates the result. It
+// returns ErrOverflow if the rounded price is larger than MAX.
+func (in *Instrument) NormalizePrice(price Decimal, mode RoundMode) (Decimal, error) {
+	if !in.TickSize.IsZero() {
+		var err error
+		if price, err = price.QuantizeToErr(in.TickSize, mode); err != nil {
+			return Zero, err
+		}
+	}
+	if err := checkLimits(FieldPrice, price, in.MinPrice, in.MaxPrice); err != nil {
+		return Zero, err
+	}
+	return price, nil
+}
+
+// NormalizeQty rounds qty to a multiple of the lot size using mode and validates the result. It returns
+// ErrOverflow if the rounded quantity is larger than MAX.
+func (in *Instrument) NormalizeQty(qty Decimal, mode RoundMode) (Decimal, error) {
+	if !in.LotSize.IsZero() {
+		var err error
+		if qty, err = qty.QuantizeToErr(in.LotSize, mode); err != nil {
+			return Zero, err
+		}
+	}
+	if err := checkLimits(FieldQty, qty, in.MinQty, in.MaxQty); err != nil {
+		return Zero, err
+	}
+	return qty, nil
+}
+
+// checkLimits returns a *ValidationError if value is less than min or, when max is not zero, greater than max
+func checkLimits(field string, value, min, max Decimal) error {
+	if value.fp < min.fp {
+		return &ValidationError{Field: field, Value: value, Limit: min, Err: ErrBelowMin}
+	}
+	if max.fp != 0 && value.fp > max.fp {
+		return &ValidationError{Field: field, Value: value, Limit: max, Err: ErrAboveMax}
+	}
+	return nil
+}
+
+// FormatPrice formats price with the instrument's display precision, rounding half to even as %.*f does
+func (in *Instrument) FormatPrice(price Decimal) string {
+	var buffer [32]byte
+	return string(in.AppendPrice(buffer[:0], price))
+}
+
+// AppendPrice appends price formatted as by FormatPrice to dst and returns the extended buffer
+func (in *Instrument) AppendPrice(dst []byte, price Decimal) []byte {
+	prec := in.Precision
+	if prec < 0 {
+		prec = 0
+	}
+	var buffer [24]byte
+	var ds digits
+	ds.set(price.AppendString(buffer[:0]))
+	ds.round(ds.dp + prec)
+	return ds.appendF(dst, prec)
+}
@@ -0,0 +1,179 @@
+package udecimal_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var btcusd = Instrument{
+	TickSize:    MustParse("0.05"),
+	LotSize:     MustParse("0.001"),
+	MinPrice:    MustParse("1"),
+	MaxPrice:    MustParse("1000000"),
+	MinQty:      MustParse("0.001"),
+	MaxQty:      MustParse("100"),
+	MinNotional: MustParse("10"),
+	Precision:   2,
+}
+
+func TestValidatePrice(t *testing.T) {
+	assert.NoError(t, btcusd.ValidatePrice(MustParse("101.35")))
+	assert.NoError(t, btcusd.ValidatePrice(MustParse("1")))
+	assert.NoError(t, btcusd.ValidatePrice(MustParse("1000000")))
+
+	err := btcusd.ValidatePrice(MustParse("101.37"))
+	assert.True(t, errors.Is(err, ErrTickSize))
+	assert.EqualError(t, err, "price 101.37 not a multiple of the tick size 0.05")
+
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, FieldPrice, verr.Field)
+		assert.Equal(t, MustParse("101.37"), verr.Value)
+		assert.Equal(t, btcusd.TickSize, verr.Limit)
+	}
+
+	err = btcusd.ValidatePrice(MustParse("0.95"))
+	assert.True(t, errors.Is(err, ErrBelowMin))
+	assert.EqualError(t, err, "price 0.95 below the minimum 1")
+
+	err = btcusd.ValidatePrice(MustParse("1000000.05"))
+	assert.True(t, errors.Is(err, ErrAboveMax))
+	assert.EqualError(t, err, "price 1000000.05 above the maximum 1000000")
+
+	var unchecked Instrument
+	assert.NoError(t, unchecked.ValidatePrice(MustParse("0.12345678")))
+	assert.NoError(t, unchecked.ValidatePrice(Max))
+	assert.NoError(t, unchecked.ValidatePrice(Zero))
+}
+
+func TestValidateQty(t *testing.T) {
+	assert.NoError(t, btcusd.ValidateQty(MustParse("0.001")))
+	assert.NoError(t, btcusd.ValidateQty(MustParse("12.345")))
+
+	err := btcusd.ValidateQty(MustParse("12.3456"))
+	assert.True(t, errors.Is(err, ErrLotSize))
+	assert.EqualError(t, err, "quantity 12.3456 not a multiple of the lot size 0.001")
+
+	err = btcusd.ValidateQty(Zero)
+	assert.True(t, errors.Is(err, ErrBelowMin))
+	assert.EqualError(t, err, "quantity 0 below the minimum 0.001")
+
+	err = btcusd.ValidateQty(MustParse("100.001"))
+	assert.True(t, errors.Is(err, ErrAboveMax))
+	assert.False(t, errors.Is(err, ErrBelowMin))
+}
+
+func TestValidateOrder(t *testing.T) {
+	assert.NoError(t, btcusd.ValidateOrder(MustParse("100"), MustParse("0.1")))
+	assert.NoError(t, btcusd.ValidateOrder(MustParse("1000000"), MustParse("100")))
+
+	err := btcusd.ValidateOrder(MustParse("100"), MustParse("0.099"))
+	assert.True(t, errors.Is(err, ErrBelowMin))
+	assert.EqualError(t, err, "notional 9.9 below the minimum 10")
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, FieldNotional, verr.Field)
+	}
+
+	// the price is checked before the quantity
+	err = btcusd.ValidateOrder(MustParse("100.01"), MustParse("0.0001"))
+	assert.True(t, errors.Is(err, ErrTickSize))
+	err = btcusd.ValidateOrder(MustParse("100"), MustParse("0.0001"))
+	assert.True(t, errors.Is(err, ErrLotSize))
+
+	// a notional larger than MAX passes the minimum
+	in := Instrument{MinNotional: MustParse("1")}
+	assert.NoError(t, in.ValidateOrder(Max, Max))
+	assert.Error(t, in.ValidateOrder(MustParse("0.5"), MustParse("1.99999999")))
+}
+
+func TestNormalizePrice(t *testing.T) {
+	f, err := btcusd.NormalizePrice(MustParse("101.37"), RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "101.35", f.String())
+
+	f, err = btcusd.NormalizePrice(MustParse("101.36"), RoundCeiling)
+	assert.NoError(t, err)
+	assert.Equal(t, "101.4", f.String())
+
+	f, err = btcusd.NormalizePrice(MustParse("0.99"), RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", f.String())
+
+	_, err = btcusd.NormalizePrice(MustParse("0.97"), RoundFloor)
+	assert.True(t, errors.Is(err, ErrBelowMin))
+	assert.EqualError(t, err, "price 0.95 below the minimum 1")
+
+	_, err = btcusd.NormalizePrice(MustParse("1000000.01"), RoundUp)
+	assert.True(t, errors.Is(err, ErrAboveMax))
+
+	in := Instrument{TickSize: MustParse("0.3")}
+	_, err = in.NormalizePrice(Max, RoundUp)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestNormalizeQty(t *testing.T) {
+	f, err := btcusd.NormalizeQty(MustParse("12.34567"), RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.345", f.String())
+
+	_, err = btcusd.NormalizeQty(MustParse("0.0009"), RoundDown)
+	assert.True(t, errors.Is(err, ErrBelowMin))
+
+	f, err = btcusd.NormalizeQty(MustParse("0.0009"), RoundUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.001", f.String())
+
+	var unchecked Instrument
+	f, err = unchecked.NormalizeQty(MustParse("0.12345678"), RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.12345678", f.String())
+}
+
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		price     string
+		precision int
+		expected  string
+	}{
+		{"101.3", 2, "101.30"},
+		{"101.35", 2, "101.35"},
+		{"101.355", 2, "101.36"},
+		{"101.345", 2, "101.34"},
+		{"0", 2, "0.00"},
+		{"0.00000001", 4, "0.0000"},
+		{"101.5", 0, "102"},
+		{"101.5", -1, "102"},
+		{"1.5", 10, "1.5000000000"},
+		{"99999999999.99999999", 2, "100000000000.00"},
+	}
+
+	for _, tt := range tests {
+		in := Instrument{Precision: tt.precision}
+		f := MustParse(tt.price)
+		assert.Equal(t, tt.expected, in.FormatPrice(f), tt.price)
+		if tt.precision >= 0 {
+			assert.Equal(t, fmt.Sprintf("%.*f", tt.precision, f), in.FormatPrice(f), tt.price)
+		}
+	}
+
+	assert.Equal(t, "px=101.30", string(btcusd.AppendPrice([]byte("px="), MustParse("101.3"))))
+}
+
+func TestInstrumentAllocs(t *testing.T) {
+	price := MustParse("101.35")
+	qty := MustParse("0.1")
+	buf := make([]byte, 0, 32)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = btcusd.ValidateOrder(price, qty)
+		_, _ = btcusd.NormalizePrice(price, RoundHalfEven)
+		_, _ = btcusd.NormalizeQty(qty, RoundDown)
+		buf = btcusd.AppendPrice(buf[:0], price)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,42 @@
+package udecimal
+
+import "encoding/binary"
+
+// KeySize is the length of a Decimal encoded with AppendKey or AppendKeyDesc
+const KeySize = 8
+
+// AppendKey appends f to dst as a fixed width, order preserving key and returns the extended buffer.
+// For any a and b, bytes.Compare on their keys equals a.Cmp(b), so keys can be used directly in sorted
+// stores.
+func (f Decimal) AppendKey(dst []byte) []byte {
+	var buffer [KeySize]byte
+	binary.BigEndian.PutUint64(buffer[:], f.fp)
+	return append(dst, buffer[:]...)
+}
+
+// AppendKeyDesc appends f to dst as a fixed width key that sorts in descending order, such as for the bid
+// side of a book. For any a and b, bytes.Compare on their keys equals b.Cmp(a).
+func (f Decimal) AppendKeyDesc(dst []byte) []byte {
+	var buffer [KeySize]byte
+	binary.BigEndian.PutUint64(buffer[:], ^f.fp)
+	return append(dst, buffer[:]...)
+}
+
+// DecodeKey decodes a Decimal encoded with AppendKey from the start of b, returning the remaining bytes.
+// Like ReadFrom, it accepts any value that AppendKey can write, including the values above MAX that Add can
+// return.
+func DecodeKey(b []byte) (Decimal, []byte, error) {
+	if len(b) < KeySize {
+		return Zero, b, errFormat
+	}
+	return Decimal{fp: binary.BigEndian.Uint64(b)}, b[KeySize:], nil
+}
+
+// DecodeKeyDesc decodes a Decimal encoded with AppendKeyDesc from the start of b, returning the remaining
+// bytes
+func DecodeKeyDesc(b []byte) (Decimal, []byte, error) {
+	if len(b) < KeySize {
+		return Zero, b, errFormat
+	}
+	return Decimal{fp: ^binary.BigEndian.Uint64(b)}, b[KeySize:], nil
+}
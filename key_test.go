@@ -0,0 +1,89 @@
+package udecimal_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	f := MustParse("1234.5678")
+
+	key := f.AppendKey([]byte("p:"))
+	assert.Equal(t, 2+KeySize, len(key))
+
+	f0, rem, err := DecodeKey(append(key[2:], 'x'))
+	assert.NoError(t, err)
+	assert.Equal(t, f, f0)
+	assert.Equal(t, []byte("x"), rem)
+
+	key = f.AppendKeyDesc(nil)
+	f0, rem, err = DecodeKeyDesc(key)
+	assert.NoError(t, err)
+	assert.Equal(t, f, f0)
+	assert.Empty(t, rem)
+
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 1}, SmallestUnit.AppendKey(nil))
+	assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}, SmallestUnit.AppendKeyDesc(nil))
+
+	// Add can return values above MAX, and their keys still decode and sort
+	large := MustParse("90000000000").Add(MustParse("90000000000"))
+	assert.Equal(t, 1, bytes.Compare(large.AppendKey(nil), Max.AppendKey(nil)))
+	assert.Equal(t, -1, bytes.Compare(large.AppendKeyDesc(nil), Max.AppendKeyDesc(nil)))
+
+	for _, d := range []Decimal{Zero, Max, large} {
+		f0, _, err = DecodeKey(d.AppendKey(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, d, f0)
+		f0, _, err = DecodeKeyDesc(d.AppendKeyDesc(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, d, f0)
+	}
+
+	_, _, err = DecodeKey([]byte{1, 2, 3})
+	assert.Error(t, err)
+	_, _, err = DecodeKeyDesc(nil)
+	assert.Error(t, err)
+}
+
+func TestKeyOrder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+	random := func() Decimal {
+		switch rnd.Intn(4) {
+		case 0:
+			return MustParse(randomUnits(rnd).String())
+		case 1:
+			return MustParse(randomUnits(rnd).Truncate(2).String())
+		case 2:
+			return Zero
+		}
+		return Max
+	}
+
+	for i := 0; i < 20000; i++ {
+		a := random()
+		b := random()
+		if rnd.Intn(8) == 0 {
+			b = a
+		}
+
+		assert.Equal(t, a.Cmp(b), bytes.Compare(a.AppendKey(nil), b.AppendKey(nil)), "%s %s", a, b)
+		assert.Equal(t, b.Cmp(a), bytes.Compare(a.AppendKeyDesc(nil), b.AppendKeyDesc(nil)), "%s %s", a, b)
+	}
+}
+
+func TestKeyAllocs(t *testing.T) {
+	f := MustParse("1234.5678")
+	buf := make([]byte, 0, KeySize)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.AppendKey(buf[:0])
+		_, _, _ = DecodeKey(buf)
+		buf = f.AppendKeyDesc(buf[:0])
+		_, _, _ = DecodeKeyDesc(buf)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,43 @@
+package udecimal
+
+import "math/bits"
+
+// MulDiv returns f * num / den rounded once to 8 decimal places according to mode. The product is kept
+// exactly in 128 bits, so the result is correct whenever it fits even if f * num does not, as in pro-rata
+// allocations (qty * share / total) and conversions (amount * rate / unit). It returns ErrDivisionByZero if
+// den is zero and ErrOverflow if the result is larger than MAX.
+func (f Decimal) MulDiv(num, den Decimal, mode RoundMode) (Decimal, error) {
+	// (f/scale * num/scale) / (den/scale) is f*num/den units
+	fp, err := mulDivUnits(f.fp, num.fp, 0, den.fp, mode)
+	return Decimal{fp: fp}, err
+}
+
+// MulAddDiv returns (f * num + add) / den rounded once to 8 decimal places according to mode, with the
+// same exact intermediate as MulDiv. It returns ErrDivisionByZero if den is zero and ErrOverflow if the
+// result is larger than MAX.
+func (f Decimal) MulAddDiv(num, add, den Decimal, mode RoundMode) (Decimal, error) {
+	fp, err := mulDivUnits(f.fp, num.fp, add.fp, den.fp, mode)
+	return Decimal{fp: fp}, err
+}
+
+// mulDivUnits computes (a*b + c*scale) / d for fixed point values, rounding according to mode. The
+// numerator fits in 128 bits for values up to maxFP, but Add can produce larger values, so a carry out of
+// the numerator is reported as ErrOverflow.
+func mulDivUnits(a, b, c, d uint64, mode RoundMode) (uint64, error) {
+	if d == 0 {
+		return 0, ErrDivisionByZero
+	}
+	hi, lo := bits.Mul64(a, b)
+	if c != 0 {
+		chi, clo := bits.Mul64(c, scale)
+		var carry uint64
+		lo, carry = bits.Add64(lo, clo, 0)
+		if hi, carry = bits.Add64(hi, chi, carry); carry != 0 {
+			return 0, ErrOverflow
+		}
+	}
+	if hi >= d {
+		return 0, ErrOverflow
+	}
+	return quoUnits(hi, lo, d, mode)
+}
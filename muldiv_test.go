@@ -0,0 +1,141 @@
+package udecimal_test
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMulDivSingleRounding(t *testing.T) {
+	// pro-rata fill where Mul then Div rounds twice
+	qty, share, total := MustParse("1000"), MustParse("1"), MustParse("3")
+	f, err := qty.MulDiv(share, total, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "333.33333333", f.String())
+
+	f, err = MustParse("0.00000001").MulDiv(MustParse("0.5"), MustParse("0.5"), RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.00000001", f.String())
+	assert.Equal(t, "0", MustParse("0.00000001").Mul(MustParse("0.5")).Div(MustParse("0.5")).String())
+
+	// the intermediate product is larger than MAX but the result fits
+	f, err = MustParse("50000000000").MulDiv(MustParse("40000000000"), MustParse("80000000000"), RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "25000000000", f.String())
+
+	f, err = Max.MulDiv(Max, Max, RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, Max, f)
+
+	// conversion with a rate quoted per 100 units
+	f, err = MustParse("2500").MulDiv(MustParse("91.2345"), MustParse("100"), RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2280.8625", f.String())
+}
+
+func TestMulDivErrors(t *testing.T) {
+	_, err := MustParse("1").MulDiv(MustParse("1"), Zero, RoundDown)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+	_, err = MustParse("1").MulAddDiv(MustParse("1"), MustParse("1"), Zero, RoundDown)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+
+	_, err = Max.MulDiv(MustParse("2"), MustParse("1"), RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = Max.MulDiv(Max, SmallestUnit, RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = Max.MulAddDiv(MustParse("1"), SmallestUnit, MustParse("1"), RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	// a result of exactly MAX fits, but rounding up past it overflows
+	_, err = Max.MulDiv(MustParse("1.00000001"), MustParse("1.00000001"), RoundDown)
+	assert.NoError(t, err)
+	_, err = Max.MulAddDiv(MustParse("2"), SmallestUnit, MustParse("2"), RoundUp)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	f, err := Max.MulAddDiv(MustParse("2"), SmallestUnit, MustParse("2"), RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, Max, f)
+}
+
+func TestMulAddDiv(t *testing.T) {
+	// volume weighted average price: (avg * qty + px * fill) / (qty + fill), with px * fill precomputed
+	f, err := MustParse("100.25").MulAddDiv(MustParse("3"), MustParse("202"), MustParse("5"), RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "100.55", f.String())
+
+	f, err = MustParse("2").MulAddDiv(MustParse("3"), MustParse("1"), MustParse("3"), RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.33333333", f.String())
+
+	f, err = MustParse("2").MulAddDiv(MustParse("3"), MustParse("1"), MustParse("3"), RoundUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.33333334", f.String())
+
+	f, err = Max.MulAddDiv(Max, Zero, Max, RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, Max, f)
+	_, err = Max.MulAddDiv(Max, Max, Max, RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	// Add does not check against MAX, so operands can make the 128 bit numerator carry out
+	large := Max.Add(FromUnits(8446744073709551615))
+	_, err = large.MulAddDiv(large, Max, large, RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+// TestMulDivRat compares MulDiv and MulAddDiv with the exact big.Rat result rounded by FromRat
+func TestMulDivRat(t *testing.T) {
+	rnd := rand.New(rand.NewSource(4))
+	random := func() Decimal {
+		return FromUnits(rnd.Uint64() % (Max.Units() + 1) / pow10Units[rnd.Intn(len(pow10Units))])
+	}
+
+	var x, y big.Rat
+	for i := 0; i < 20000; i++ {
+		a, b, c, d := random(), random(), random(), random()
+		if d.IsZero() {
+			continue
+		}
+		mode := roundModes[rnd.Intn(len(roundModes))]
+
+		x.Mul(a.Rat(), b.Rat())
+		x.Quo(&x, d.Rat())
+		expected, expectedErr := FromRat(&x, mode)
+		f, err := a.MulDiv(b, d, mode)
+		if expectedErr != nil {
+			assert.True(t, errors.Is(err, ErrOverflow), "%s * %s / %s %v", a, b, d, mode)
+		} else {
+			assert.NoError(t, err, "%s * %s / %s %v", a, b, d, mode)
+			assert.Equal(t, expected, f, "%s * %s / %s %v", a, b, d, mode)
+		}
+
+		y.Mul(a.Rat(), b.Rat())
+		y.Add(&y, c.Rat())
+		y.Quo(&y, d.Rat())
+		expected, expectedErr = FromRat(&y, mode)
+		f, err = a.MulAddDiv(b, c, d, mode)
+		if expectedErr != nil {
+			assert.True(t, errors.Is(err, ErrOverflow), "(%s * %s + %s) / %s %v", a, b, c, d, mode)
+		} else {
+			assert.NoError(t, err, "(%s * %s + %s) / %s %v", a, b, c, d, mode)
+			assert.Equal(t, expected, f, "(%s * %s + %s) / %s %v", a, b, c, d, mode)
+		}
+	}
+}
+
+// pow10Units spreads random values over the magnitudes of the representable range
+var pow10Units = []uint64{1, 10, 1000, 100000, 10000000, 1000000000, 100000000000, 10000000000000, 1000000000000000, 100000000000000000}
+
+func TestMulDivAllocs(t *testing.T) {
+	a, b, c := MustParse("1000"), MustParse("1"), MustParse("3")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = a.MulDiv(b, c, RoundHalfEven)
+		_, _ = a.MulAddDiv(b, c, c, RoundHalfEven)
+		_, _ = a.MulDiv(b, Zero, RoundHalfEven)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
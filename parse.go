@@ -0,0 +1,219 @@
+package udecimal
+
+import (
+	"errors"
+	"strconv"
+	"unsafe"
+)
+
+// ErrSyntax is the reason reported by a ParseError when the input is not a well formed decimal
+var ErrSyntax = errors.New("invalid syntax")
+
+// ErrPrecision is the reason reported by a ParseError when the input has more significant fractional
+// digits than can be represented
+var ErrPrecision = errors.New("too many fractional digits")
+
+var errCannotParse = errors.New("cannot parse")
+
+// ParseError records a failed strict parse
+type ParseError struct {
+	Input  string // the input being parsed
+	Offset int    // the byte offset within Input where the problem was found
+	Err    error  // the reason parsing failed: ErrSyntax, ErrPrecision or ErrOverflow
+}
+
+func (e *ParseError) Error() string {
+	return "decimal: parsing " + strconv.Quote(e.Input) + ": " + e.Err.Error() + " at offset " + strconv.Itoa(e.Offset)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseStrict creates a Decimal from a string, rejecting anything that is not exactly representable.
+// The input must consist of one or more digits, optionally followed by a '.' and one or more digits,
+// optionally followed by an exponent such as "e-3". Signs, whitespace, underscores, empty integer or
+// fractional parts and more than 8 significant fractional digits are rejected. Exponents are applied
+// exactly. Failures are reported as a *ParseError. The offset of an ErrOverflow is that of the digit at
+// which the value first exceeds the range, or of the exponent if the digits fit but the scaled value does
+// not.
+func ParseStrict(s string) (Decimal, error) {
+	fp, err := parseStrictUnits(s, nPlaces)
+	if err != nil {
+		return Zero, err
+	}
+	return Decimal{fp: fp}, nil
+}
+
+// parseUnits parses s into a fixed point value with the given number of decimal places, truncating any
+// further fractional digits. See Parse.
+func parseUnits(s string, places int) (uint64, error) {
+	fp, _, err := scanUnits(s, places, false)
+	if err != nil {
+		if err == ErrOverflow {
+			return 0, errTooLarge
+		}
+		return 0, errCannotParse
+	}
+	return fp, nil
+}
+
+// parseStrictUnits parses s into a fixed point value with the given number of decimal places. See
+// ParseStrict.
+func parseStrictUnits(s string, places int) (uint64, error) {
+	fp, offset, err := scanUnits(s, places, true)
+	if err != nil {
+		return 0, &ParseError{Input: s, Offset: offset, Err: err}
+	}
+	return fp, nil
+}
+
+// bytesToString returns the contents of b as a string without copying. The string must not be retained
+// beyond the lifetime of the call it is passed to.
+func bytesToString(b []byte) string {
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// maxExponent bounds the exponents accepted by scanUnits so that they cannot overflow an int. Any
+// larger exponent either overflows the value or leaves no significant digits.
+const maxExponent = 10000
+
+// scanUnits parses s into a fixed point value with the given number of decimal places in a single pass
+// over the input. In strict mode the integer and fractional parts must not be empty and fractional
+// digits beyond places must be zero, otherwise they are truncated. On failure it returns the byte offset
+// of the problem and ErrSyntax, ErrPrecision or ErrOverflow.
+func scanUnits(s string, places int, strict bool) (fp uint64, offset int, err error) {
+	unit := pow10[places]
+	maxInt := maxFP / unit
+
+	i := 0
+	var ip uint64
+	overflow := -1 // the offset of the digit at which the integer part exceeds the range
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		d := uint64(s[i] - '0')
+		if overflow != -1 || ip > (maxInt-d)/10 {
+			if overflow == -1 {
+				overflow = i
+			}
+			continue
+		}
+		ip = ip*10 + d
+	}
+	intEnd := i
+	if strict && intEnd == 0 {
+		return 0, 0, ErrSyntax
+	}
+
+	fracStart, fracEnd := i, i
+	var frac uint64
+	excess := -1
+	if i < len(s) && s[i] == '.' {
+		i++
+		fracStart = i
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			if n := i - fracStart; n < places {
+				frac = frac*10 + uint64(s[i]-'0')
+			} else if excess == -1 && s[i] != '0' {
+				excess = i
+			}
+		}
+		fracEnd = i
+		if strict && fracEnd == fracStart {
+			return 0, i, ErrSyntax
+		}
+	}
+	if intEnd == 0 && fracEnd == fracStart {
+		return 0, i, ErrSyntax
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		return scanExponent(s, i, intEnd, fracStart, fracEnd, places, strict)
+	}
+	if i < len(s) {
+		return 0, i, ErrSyntax
+	}
+
+	if overflow != -1 {
+		return 0, overflow, ErrOverflow
+	}
+	if strict && excess != -1 {
+		return 0, excess, ErrPrecision
+	}
+	if n := fracEnd - fracStart; n < places {
+		frac *= pow10[places-n]
+	}
+	return ip*unit + frac, 0, nil
+}
+
+// scanExponent completes scanUnits for an input with an exponent starting at s[i]. The mantissa digits
+// are s[:intEnd] and s[fracStart:fracEnd]. As the exponent determines which digits are significant the
+// mantissa digits are scanned again.
+func scanExponent(s string, i, intEnd, fracStart, fracEnd, places int, strict bool) (uint64, int, error) {
+	expOffset := i
+	exp, i, ok := scanExponentValue(s, i)
+	if !ok {
+		return 0, i, ErrSyntax
+	}
+
+	// the last mantissa digit is worth 10^shift units
+	shift := exp - (fracEnd - fracStart) + places
+	digits := intEnd + (fracEnd - fracStart)
+
+	var fp uint64
+	for k := 0; k < digits; k++ {
+		offset := k
+		if offset >= intEnd {
+			offset = fracStart + k - intEnd
+		}
+		d := uint64(s[offset] - '0')
+
+		if weight := shift + digits - 1 - k; weight < 0 {
+			if strict && d != 0 {
+				return 0, offset, ErrPrecision
+			}
+			continue
+		}
+
+		if fp > (maxFP-d)/10 {
+			return 0, offset, ErrOverflow
+		}
+		fp = fp*10 + d
+	}
+
+	if shift > 0 && fp != 0 {
+		if shift >= len(pow10) || fp > maxFP/pow10[shift] {
+			// the digits fit, so it is the exponent that makes the value too large
+			return 0, expOffset, ErrOverflow
+		}
+		fp *= pow10[shift]
+	}
+	return fp, 0, nil
+}
+
+// scanExponentValue parses the exponent starting with the 'e' or 'E' at s[i], which must end the input. The
+// magnitude is capped at maxExponent. On failure it returns false and the offset of the problem.
+func scanExponentValue(s string, i int) (exp int, offset int, ok bool) {
+	i++
+	neg := false
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		neg = s[i] == '-'
+		i++
+	}
+	expStart := i
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		if exp < maxExponent {
+			exp = exp*10 + int(s[i]-'0')
+		}
+	}
+	if i == expStart || i < len(s) {
+		return 0, i, false
+	}
+	if neg {
+		exp = -exp
+	}
+	return exp, i, true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,173 @@
+package udecimal_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStrict(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "0"},
+		{"00", "0"},
+		{"123", "123"},
+		{"123.456", "123.456"},
+		{"0.00000001", "0.00000001"},
+		{"1.000000000000", "1"},
+		{"1.123456780", "1.12345678"},
+		{"99999999999.99999999", "99999999999.99999999"},
+		{"000099999999999.99999999", "99999999999.99999999"},
+		{"1e3", "1000"},
+		{"1E3", "1000"},
+		{"1e+3", "1000"},
+		{"1.5e3", "1500"},
+		{"15e-1", "1.5"},
+		{"123456789e-8", "1.23456789"},
+		{"12345678900e-10", "1.23456789"},
+		{"9.999999999999999999e10", "99999999999.99999999"},
+		{"0.000000012e1", "0.00000012"},
+		{"0e999999999999", "0"},
+		{"1e0", "1"},
+	}
+
+	for _, tt := range tests {
+		f, err := ParseStrict(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, f.String(), tt.input)
+		}
+	}
+}
+
+func TestParseStrictErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		err    error
+	}{
+		{"", 0, ErrSyntax},
+		{".", 0, ErrSyntax},
+		{".5", 0, ErrSyntax},
+		{"5.", 2, ErrSyntax},
+		{"+1", 0, ErrSyntax},
+		{"-1", 0, ErrSyntax},
+		{" 1", 0, ErrSyntax},
+		{"1 ", 1, ErrSyntax},
+		{"1 000", 1, ErrSyntax},
+		{"1_000", 1, ErrSyntax},
+		{"1.2.3", 3, ErrSyntax},
+		{"1,5", 1, ErrSyntax},
+		{"abc", 0, ErrSyntax},
+		{"NaN", 0, ErrSyntax},
+		{"Inf", 0, ErrSyntax},
+		{"1e", 2, ErrSyntax},
+		{"1e+", 3, ErrSyntax},
+		{"1e3.5", 3, ErrSyntax},
+		{"0x10", 1, ErrSyntax},
+		{"1.123456789", 10, ErrPrecision},
+		{"1.000000001", 10, ErrPrecision},
+		{"123456789e-9", 8, ErrPrecision},
+		{"1e-9", 0, ErrPrecision},
+		{"1e-99999999999999", 0, ErrPrecision},
+		{"100000000000", 11, ErrOverflow},
+		{"99999999999.99999999e1", 20, ErrOverflow},
+		{"1e11", 1, ErrOverflow},
+		{"1e999999999999", 1, ErrOverflow},
+		{"18446744073709551616", 11, ErrOverflow},
+		{"123456789012.5", 11, ErrOverflow},
+		{"1000000000000e-1", 13, ErrOverflow},
+		{"123456789012345678900e-9", 19, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseStrict(tt.input)
+		var perr *ParseError
+		if assert.True(t, errors.As(err, &perr), tt.input) {
+			assert.Equal(t, tt.input, perr.Input)
+			assert.Equal(t, tt.offset, perr.Offset, tt.input)
+			assert.True(t, errors.Is(err, tt.err), "%s: %v", tt.input, err)
+		}
+	}
+
+	_, err := ParseStrict("1.2x")
+	assert.EqualError(t, err, `decimal: parsing "1.2x": invalid syntax at offset 3`)
+	_, err = ParseStrict("100000000000")
+	assert.EqualError(t, err, `decimal: parsing "100000000000": decimal overflow at offset 11`)
+}
+
+func TestParseStrictScaled(t *testing.T) {
+	f, err := ParseStrictDecimal2("123.45")
+	assert.NoError(t, err)
+	assert.Equal(t, "123.45", f.String())
+
+	f, err = ParseStrictDecimal2("1.2345e2")
+	assert.NoError(t, err)
+	assert.Equal(t, "123.45", f.String())
+
+	_, err = ParseStrictDecimal2("123.456")
+	assert.True(t, errors.Is(err, ErrPrecision))
+
+	f4, err := ParseStrictDecimal4("0.0001")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0001", f4.String())
+
+	_, err = ParseStrictDecimal6("0.0000001")
+	assert.True(t, errors.Is(err, ErrPrecision))
+}
+
+func TestParseLenient(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "0"},
+		{"123", "123"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"123.456", "123.456"},
+		{"1.123456789", "1.12345678"},
+		{"99999999999.12345678901234567890", "99999999999.12345678"},
+		{"1.5e3", "1500"},
+		{"15E-1", "1.5"},
+		{"1.123456789e1", "11.23456789"},
+		{"1.1234567899e0", "1.12345678"},
+		{"100000000000e-1", "10000000000"},
+	}
+
+	for _, tt := range tests {
+		f, err := Parse(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, f.String(), tt.input)
+		}
+
+		f, err = ParseBytes([]byte(tt.input))
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, f.String(), tt.input)
+		}
+	}
+
+	for _, s := range []string{"", ".", "e5", "1e", "abc", "1.2.3", "-1", "+1", " 1", "1_000", "NaN", "100000000000", "1e11", "18446744073709551616"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+
+		_, err = ParseBytes([]byte(s))
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	s := "12345678.12345678"
+	b := []byte("1.5e3")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = Parse(s)
+		_, _ = ParseBytes(b)
+		_, _ = Parse("abc")
+		_, _ = ParseBytes(b[:2])
+	})
+	assert.Equal(t, float64(0), allocs)
+}
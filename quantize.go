@@ -0,0 +1,73 @@
+package udecimal
+
+// quantizeUnits rounds the fixed point value fp to a multiple of step according to mode. It returns false
+// if the rounded value is larger than maxFP.
+func quantizeUnits(fp, step uint64, mode RoundMode) (uint64, bool) {
+	if step == 0 {
+		panic("decimal division by zero")
+	}
+	q, r := fp/step, fp%step
+	if roundUp(q, r, step, mode) {
+		q++
+	}
+	if q > maxFP/step {
+		return 0, false
+	}
+	return q * step, true
+}
+
+// QuantizeTo returns f rounded to a multiple of step, such as an instrument's tick or lot size, using the
+// specified rounding mode. The result is exact for any step. QuantizeTo panics if step is zero or if the
+// rounded value overflows.
+func (f Decimal) QuantizeTo(step Decimal, mode RoundMode) Decimal {
+	fp, ok := quantizeUnits(f.fp, step.fp, mode)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return Decimal{fp: fp}
+}
+
+// QuantizeToErr rounds f to a multiple of step like QuantizeTo, returning ErrDivisionByZero or ErrOverflow
+// instead of panicking
+func (f Decimal) QuantizeToErr(step Decimal, mode RoundMode) (Decimal, error) {
+	if step.fp == 0 {
+		return Zero, ErrDivisionByZero
+	}
+	fp, ok := quantizeUnits(f.fp, step.fp, mode)
+	if !ok {
+		return Zero, ErrOverflow
+	}
+	return Decimal{fp: fp}, nil
+}
+
+// FloorTo returns the largest multiple of step that is not larger than f. It panics if step is zero.
+func (f Decimal) FloorTo(step Decimal) Decimal {
+	return f.QuantizeTo(step, RoundFloor)
+}
+
+// CeilTo returns the smallest multiple of step that is not smaller than f. It panics if step is zero or if
+// the result overflows.
+func (f Decimal) CeilTo(step Decimal) Decimal {
+	return f.QuantizeTo(step, RoundCeiling)
+}
+
+// IsMultipleOf returns true if f is an exact multiple of step. Only zero is a multiple of a zero step.
+func (f Decimal) IsMultipleOf(step Decimal) bool {
+	if step.fp == 0 {
+		return f.fp == 0
+	}
+	return f.fp%step.fp == 0
+}
+
+// StepsBetween returns the number of whole steps between a and b, in either order, so that the distance
+// between two prices on the same tick grid is counted in ticks. A partial step is not counted.
+// StepsBetween panics if step is zero.
+func StepsBetween(a, b, step Decimal) uint64 {
+	if step.fp == 0 {
+		panic("decimal division by zero")
+	}
+	if a.fp > b.fp {
+		a, b = b, a
+	}
+	return (b.fp - a.fp) / step.fp
+}
@@ -0,0 +1,175 @@
+package udecimal_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// quantizeSteps are awkward tick and lot sizes
+var quantizeSteps = []string{"0.00000001", "0.00000003", "0.00000025", "0.00000007", "0.001", "0.05", "0.12345678", "0.3", "1", "2.5", "7", "1000"}
+
+// referenceQuantize rounds fp to a multiple of step by comparing the distances to the neighbouring multiples
+func referenceQuantize(fp, step uint64, mode RoundMode) (uint64, bool) {
+	lo := fp - fp%step
+	if lo == fp {
+		return fp, true
+	}
+	hi := lo + step
+	below, above := fp-lo, hi-fp
+
+	up := false
+	switch mode {
+	case RoundHalfUp:
+		up = above <= below
+	case RoundHalfDown:
+		up = above < below
+	case RoundHalfEven:
+		up = above < below || (above == below && (lo/step)%2 == 1)
+	case RoundUp, RoundCeiling:
+		up = true
+	}
+	if !up {
+		return lo, true
+	}
+	return hi, hi <= Max.Units() && hi > lo
+}
+
+func TestQuantizeTo(t *testing.T) {
+	tests := []struct {
+		value    string
+		step     string
+		mode     RoundMode
+		expected string
+	}{
+		{"101.37", "0.05", RoundHalfUp, "101.35"},
+		{"101.375", "0.05", RoundHalfUp, "101.4"},
+		{"101.375", "0.05", RoundHalfDown, "101.35"},
+		{"101.375", "0.05", RoundHalfEven, "101.4"},
+		{"101.325", "0.05", RoundHalfEven, "101.3"},
+		{"101.30000001", "0.05", RoundCeiling, "101.35"},
+		{"101.34999999", "0.05", RoundFloor, "101.3"},
+		{"0.00000049", "0.00000025", RoundDown, "0.00000025"},
+		{"0.00000049", "0.00000025", RoundUp, "0.0000005"},
+		{"0.00000037", "0.00000025", RoundHalfUp, "0.00000025"},
+		{"0.00000038", "0.00000025", RoundHalfDown, "0.0000005"},
+		{"1.23456789", "0.001", RoundHalfEven, "1.235"},
+		{"0", "0.05", RoundUp, "0"},
+		{"99999999999.99999999", "0.00000001", RoundUp, "99999999999.99999999"},
+		{"99999999999.99999999", "1000", RoundDown, "99999999000"},
+	}
+
+	for _, tt := range tests {
+		f := MustParse(tt.value).QuantizeTo(MustParse(tt.step), tt.mode)
+		assert.Equal(t, tt.expected, f.String(), "%s to %s %v", tt.value, tt.step, tt.mode)
+	}
+
+	assert.Panics(t, func() { MustParse("1").QuantizeTo(Zero, RoundDown) })
+	assert.Panics(t, func() { Max.QuantizeTo(MustParse("1000"), RoundUp) })
+}
+
+func TestQuantizeToExhaustive(t *testing.T) {
+	for _, s := range quantizeSteps {
+		step := MustParse(s)
+		// every value up to a few steps, and the values around multiples of the step up to MAX
+		var values []uint64
+		for fp := uint64(0); fp < 4*step.Units()+10 && fp < 100000; fp++ {
+			values = append(values, fp)
+		}
+		for _, m := range []uint64{1, 2, 1000, 1234567, Max.Units() / step.Units()} {
+			base := m * step.Units()
+			for d := uint64(0); d <= 3; d++ {
+				values = append(values, base-d, base+d, base+step.Units()/2-d, base+step.Units()/2+d)
+			}
+		}
+
+		for _, fp := range values {
+			if fp > Max.Units() {
+				continue
+			}
+			f := FromUnits(fp)
+			for _, mode := range roundModes {
+				expected, ok := referenceQuantize(fp, step.Units(), mode)
+				result, err := f.QuantizeToErr(step, mode)
+				switch {
+				case !ok:
+					if !errors.Is(err, ErrOverflow) {
+						t.Fatalf("%s to %s %v: expected overflow, got %s, %v", f, step, mode, result, err)
+					}
+				case err != nil:
+					t.Fatalf("%s to %s %v: %v", f, step, mode, err)
+				case result.Units() != expected || !result.IsMultipleOf(step):
+					t.Fatalf("%s to %s %v: expected %s, got %s", f, step, mode, FromUnits(expected), result)
+				}
+			}
+		}
+	}
+}
+
+func TestQuantizeToErr(t *testing.T) {
+	f, err := MustParse("1.07").QuantizeToErr(MustParse("0.05"), RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.05", f.String())
+
+	_, err = MustParse("1").QuantizeToErr(Zero, RoundDown)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+	_, err = MustParse("99999999999.9").QuantizeToErr(MustParse("0.25"), RoundCeiling)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestFloorToCeilTo(t *testing.T) {
+	tick := MustParse("0.00000025")
+	assert.Equal(t, "1.00000025", MustParse("1.00000049").FloorTo(tick).String())
+	assert.Equal(t, "1.0000005", MustParse("1.00000026").CeilTo(tick).String())
+	assert.Equal(t, "1.0000005", MustParse("1.0000005").FloorTo(tick).String())
+	assert.Equal(t, "1.0000005", MustParse("1.0000005").CeilTo(tick).String())
+
+	lot := MustParse("0.001")
+	assert.Equal(t, "0.123", MustParse("0.12399999").FloorTo(lot).String())
+	assert.Equal(t, "0.124", MustParse("0.12300001").CeilTo(lot).String())
+
+	assert.Panics(t, func() { MustParse("1").FloorTo(Zero) })
+	assert.Panics(t, func() { Max.CeilTo(MustParse("0.3")) })
+}
+
+func TestIsMultipleOf(t *testing.T) {
+	tick := MustParse("0.05")
+	assert.True(t, MustParse("101.35").IsMultipleOf(tick))
+	assert.True(t, Zero.IsMultipleOf(tick))
+	assert.False(t, MustParse("101.36").IsMultipleOf(tick))
+	assert.False(t, MustParse("101.35000001").IsMultipleOf(tick))
+	assert.True(t, MustParse("0.0000005").IsMultipleOf(MustParse("0.00000025")))
+	assert.False(t, MustParse("0.0000006").IsMultipleOf(MustParse("0.00000025")))
+	assert.True(t, Max.IsMultipleOf(SmallestUnit))
+	assert.True(t, Zero.IsMultipleOf(Zero))
+	assert.False(t, MustParse("1").IsMultipleOf(Zero))
+}
+
+func TestStepsBetween(t *testing.T) {
+	tick := MustParse("0.05")
+	assert.Equal(t, uint64(7), StepsBetween(MustParse("101"), MustParse("101.35"), tick))
+	assert.Equal(t, uint64(7), StepsBetween(MustParse("101.35"), MustParse("101"), tick))
+	assert.Equal(t, uint64(6), StepsBetween(MustParse("101"), MustParse("101.34999999"), tick))
+	assert.Equal(t, uint64(0), StepsBetween(MustParse("101"), MustParse("101"), tick))
+	assert.Equal(t, uint64(3), StepsBetween(MustParse("0.00000025"), MustParse("0.000001"), MustParse("0.00000025")))
+	assert.Equal(t, Max.Units(), StepsBetween(Zero, Max, SmallestUnit))
+
+	assert.Panics(t, func() { StepsBetween(Zero, Max, Zero) })
+}
+
+func TestQuantizeAllocs(t *testing.T) {
+	f := MustParse("101.37")
+	tick := MustParse("0.05")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = f.QuantizeTo(tick, RoundHalfEven)
+		_, _ = f.QuantizeToErr(tick, RoundHalfEven)
+		_ = f.FloorTo(tick)
+		_ = f.CeilTo(tick)
+		_ = f.IsMultipleOf(tick)
+		_ = StepsBetween(f, Max, tick)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,141 @@
+package udecimal
+
+// RoundMode specifies how a value is rounded when digits have to be discarded
+type RoundMode int
+
+const (
+	// RoundHalfUp rounds to the nearest value, with ties rounded away from zero
+	RoundHalfUp RoundMode = iota
+	// RoundHalfDown rounds to the nearest value, with ties rounded towards zero
+	RoundHalfDown
+	// RoundHalfEven rounds to the nearest value, with ties rounded to the even neighbour (banker's rounding)
+	RoundHalfEven
+	// RoundDown rounds towards zero (truncation)
+	RoundDown
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundCeiling rounds towards positive infinity
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
+)
+
+// String returns the name of the rounding mode
+func (m RoundMode) String() string {
+	switch m {
+	case RoundHalfUp:
+		return "HalfUp"
+	case RoundHalfDown:
+		return "HalfDown"
+	case RoundHalfEven:
+		return "HalfEven"
+	case RoundDown:
+		return "Down"
+	case RoundUp:
+		return "Up"
+	case RoundCeiling:
+		return "Ceiling"
+	case RoundFloor:
+		return "Floor"
+	}
+	return "RoundMode(?)"
+}
+
+// pow10 holds every power of 10 that fits in a uint64
+var pow10 = [...]uint64{
+	1,
+	10,
+	100,
+	1000,
+	10000,
+	100000,
+	1000000,
+	10000000,
+	100000000,
+	1000000000,
+	10000000000,
+	100000000000,
+	1000000000000,
+	10000000000000,
+	100000000000000,
+	1000000000000000,
+	10000000000000000,
+	100000000000000000,
+	1000000000000000000,
+	10000000000000000000,
+}
+
+// roundUp reports whether the non-negative quotient q with remainder r of a division by d should be
+// incremented in order to round it according to mode
+func roundUp(q, r, d uint64, mode RoundMode) bool {
+	if r == 0 {
+		return false
+	}
+
+	switch mode {
+	case RoundHalfUp:
+		return r >= d-r
+	case RoundHalfDown:
+		return r > d-r
+	case RoundHalfEven:
+		return r > d-r || (r == d-r && q&1 == 1)
+	case RoundUp, RoundCeiling:
+		return true
+	}
+	return false
+}
+
+// roundUnits rounds the fixed point value fp with the given number of places to n decimal places.
+// It returns false if the rounded value is larger than maxFP.
+func roundUnits(fp uint64, places int, n int, mode RoundMode) (uint64, bool) {
+	if n >= places {
+		return fp, true
+	}
+
+	e := places - n
+	if e >= len(pow10) {
+		// every representable value is less than half of the rounding unit
+		if fp != 0 && (mode == RoundUp || mode == RoundCeiling) {
+			return 0, false
+		}
+		return 0, true
+	}
+
+	unit := pow10[e]
+	q, r := fp/unit, fp%unit
+	if !roundUp(q, r, unit, mode) {
+		return q * unit, true
+	}
+
+	q++
+	if q > maxFP/unit {
+		return 0, false
+	}
+	return q * unit, true
+}
+
+// RoundWith returns f rounded to n decimal places using the specified rounding mode. A negative n
+// rounds to the left of the decimal point, so -2 rounds to the nearest hundred. RoundWith panics if the
+// rounded value overflows.
+func (f Decimal) RoundWith(n int, mode RoundMode) Decimal {
+	fp, ok := roundUnits(f.fp, nPlaces, n, mode)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return Decimal{fp: fp}
+}
+
+// Truncate returns f with all digits after the nth decimal place dropped
+func (f Decimal) Truncate(n int) Decimal {
+	return f.RoundWith(n, RoundDown)
+}
+
+// Floor returns f rounded down to n decimal places
+func (f Decimal) Floor(n int) Decimal {
+	return f.RoundWith(n, RoundFloor)
+}
+
+// Ceil returns f rounded up to n decimal places
+func (f Decimal) Ceil(n int) Decimal {
+	return f.RoundWith(n, RoundCeiling)
+}
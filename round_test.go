@@ -0,0 +1,110 @@
+package udecimal_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var roundModes = []RoundMode{RoundHalfUp, RoundHalfDown, RoundHalfEven, RoundDown, RoundUp, RoundCeiling, RoundFloor}
+
+func TestRoundWith(t *testing.T) {
+	// expected results are in the order of roundModes
+	tests := []struct {
+		value    string
+		places   int
+		expected [7]string
+	}{
+		{"0", 0, [7]string{"0", "0", "0", "0", "0", "0", "0"}},
+		{"7", 3, [7]string{"7", "7", "7", "7", "7", "7", "7"}},
+		{"2.5", 0, [7]string{"3", "2", "2", "2", "3", "3", "2"}},
+		{"3.5", 0, [7]string{"4", "3", "4", "3", "4", "4", "3"}},
+		{"1.49999999", 0, [7]string{"1", "1", "1", "1", "2", "2", "1"}},
+		{"1.50000001", 0, [7]string{"2", "2", "2", "1", "2", "2", "1"}},
+		{"123456789.99999999", 0, [7]string{"123456790", "123456790", "123456790", "123456789", "123456790", "123456790", "123456789"}},
+		{"1.12345", 1, [7]string{"1.1", "1.1", "1.1", "1.1", "1.2", "1.2", "1.1"}},
+		{"1.25", 1, [7]string{"1.3", "1.2", "1.2", "1.2", "1.3", "1.3", "1.2"}},
+		{"0.145", 2, [7]string{"0.15", "0.14", "0.14", "0.14", "0.15", "0.15", "0.14"}},
+		{"0.155", 2, [7]string{"0.16", "0.15", "0.16", "0.15", "0.16", "0.16", "0.15"}},
+		{"0.999", 2, [7]string{"1", "1", "1", "0.99", "1", "1", "0.99"}},
+		{"1.12345", 3, [7]string{"1.123", "1.123", "1.123", "1.123", "1.124", "1.124", "1.123"}},
+		{"1.12345", 4, [7]string{"1.1235", "1.1234", "1.1234", "1.1234", "1.1235", "1.1235", "1.1234"}},
+		{"1.12355", 4, [7]string{"1.1236", "1.1235", "1.1236", "1.1235", "1.1236", "1.1236", "1.1235"}},
+		{"1.12345", 5, [7]string{"1.12345", "1.12345", "1.12345", "1.12345", "1.12345", "1.12345", "1.12345"}},
+		{"0.12345678", 6, [7]string{"0.123457", "0.123457", "0.123457", "0.123456", "0.123457", "0.123457", "0.123456"}},
+		{"0.1234565", 6, [7]string{"0.123457", "0.123456", "0.123456", "0.123456", "0.123457", "0.123457", "0.123456"}},
+		{"0.00000005", 7, [7]string{"0.0000001", "0", "0", "0", "0.0000001", "0.0000001", "0"}},
+		{"0.00000015", 7, [7]string{"0.0000002", "0.0000001", "0.0000002", "0.0000001", "0.0000002", "0.0000002", "0.0000001"}},
+		{"0.00000001", 7, [7]string{"0", "0", "0", "0", "0.0000001", "0.0000001", "0"}},
+		{"99999999999.99999999", 8, [7]string{"99999999999.99999999", "99999999999.99999999", "99999999999.99999999", "99999999999.99999999", "99999999999.99999999", "99999999999.99999999", "99999999999.99999999"}},
+		{"12345.6789", 9, [7]string{"12345.6789", "12345.6789", "12345.6789", "12345.6789", "12345.6789", "12345.6789", "12345.6789"}},
+		{"1250", -2, [7]string{"1300", "1200", "1200", "1200", "1300", "1300", "1200"}},
+		{"1234", -11, [7]string{"0", "0", "0", "0", "overflow", "overflow", "0"}},
+	}
+
+	for _, tt := range tests {
+		f := MustParse(tt.value)
+		for i, mode := range roundModes {
+			if tt.expected[i] == "overflow" {
+				assert.Panics(t, func() { f.RoundWith(tt.places, mode) }, "%s %d %s", tt.value, tt.places, mode)
+				continue
+			}
+			assert.Equal(t, tt.expected[i], f.RoundWith(tt.places, mode).String(), "%s %d %s", tt.value, tt.places, mode)
+		}
+	}
+}
+
+func TestRoundWithShopspring(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+
+	for places := 0; places <= 8; places++ {
+		for i := 0; i < 2000; i++ {
+			d := randomUnits(rnd)
+			if places < 8 && rnd.Intn(2) == 0 {
+				// force a tie at the rounding digit
+				d = d.Truncate(int32(places)).Add(decimal.New(5, -int32(places)-1))
+			}
+			if d.GreaterThan(decimal.RequireFromString("99999999998")) {
+				continue
+			}
+
+			f := MustParse(d.String())
+			p := int32(places)
+
+			halfDown := d.Round(p)
+			if d.Sub(d.RoundDown(p)).Equal(decimal.New(5, -p-1)) {
+				halfDown = d.RoundDown(p)
+			}
+
+			expected := []decimal.Decimal{d.Round(p), halfDown, d.RoundBank(p), d.RoundDown(p), d.RoundUp(p), d.RoundCeil(p), d.RoundFloor(p)}
+			for j, mode := range roundModes {
+				assert.Equal(t, expected[j].String(), f.RoundWith(places, mode).String(), "%s %d %s", d, places, mode)
+			}
+		}
+	}
+}
+
+func TestRoundOverflow(t *testing.T) {
+	f := MustParse("99999999999.5")
+	assert.Panics(t, func() { f.Round(0) })
+	assert.Panics(t, func() { f.Ceil(0) })
+	assert.Equal(t, "99999999999", f.Truncate(0).String())
+	assert.Equal(t, "99999999999", f.Floor(0).String())
+}
+
+func TestTruncateFloorCeil(t *testing.T) {
+	f := MustParse("1.98765432")
+	for n := 0; n <= 8; n++ {
+		assert.Equal(t, f.RoundWith(n, RoundDown), f.Truncate(n))
+		assert.Equal(t, f.RoundWith(n, RoundFloor), f.Floor(n))
+		assert.Equal(t, f.RoundWith(n, RoundCeiling), f.Ceil(n))
+	}
+
+	assert.Equal(t, "1.98", f.Truncate(2).String())
+	assert.Equal(t, "1.98", f.Floor(2).String())
+	assert.Equal(t, "1.99", f.Ceil(2).String())
+	assert.Equal(t, "2", f.Ceil(0).String())
+}
@@ -0,0 +1,41 @@
+package udecimal
+
+// SaturatingAdd adds f0 to f, clamping the result to Max if it is larger than MAX. Sums above MAX are
+// clamped even where Add would return them. The returned bool reports whether the result was clamped.
+func (f Decimal) SaturatingAdd(f0 Decimal) (Decimal, bool) {
+	fp, err := addUnits(f.fp, f0.fp)
+	if err != nil {
+		return Max, true
+	}
+	return Decimal{fp: fp}, false
+}
+
+// SaturatingSub subtracts f0 from f, clamping the result to Zero instead of going negative. The
+// returned bool reports whether the result was clamped.
+func (f Decimal) SaturatingSub(f0 Decimal) (Decimal, bool) {
+	fp, err := subUnits(f.fp, f0.fp)
+	if err != nil {
+		return Zero, true
+	}
+	return Decimal{fp: fp}, false
+}
+
+// SaturatingMul multiplies f by f0 like Mul, clamping the result to Max instead of overflowing. The
+// returned bool reports whether the result was clamped.
+func (f Decimal) SaturatingMul(f0 Decimal) (Decimal, bool) {
+	fp, err := mulUnits(f.fp, f0.fp, scale, RoundDown)
+	if err != nil {
+		return Max, true
+	}
+	return Decimal{fp: fp}, false
+}
+
+// SaturatingDiv divides f by f0 like Div, clamping the result to Max instead of overflowing. Division
+// by zero also yields Max. The returned bool reports whether the result was clamped.
+func (f Decimal) SaturatingDiv(f0 Decimal) (Decimal, bool) {
+	fp, err := divUnits(f.fp, f0.fp, scale, RoundHalfUp)
+	if err != nil {
+		return Max, true
+	}
+	return Decimal{fp: fp}, false
+}
@@ -0,0 +1,92 @@
+package udecimal_test
+
+import (
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxSmallestUnit(t *testing.T) {
+	assert.Equal(t, "99999999999.99999999", Max.String())
+	assert.Equal(t, "0.00000001", SmallestUnit.String())
+	assert.Equal(t, MustParse("99999999999.99999999"), Max)
+	assert.Equal(t, Max, Max.Sub(SmallestUnit).Add(SmallestUnit))
+}
+
+func TestSaturatingAdd(t *testing.T) {
+	f, clamped := MustParse("1.5").SaturatingAdd(MustParse("2"))
+	assert.False(t, clamped)
+	assert.Equal(t, "3.5", f.String())
+
+	f, clamped = Max.Sub(SmallestUnit).SaturatingAdd(SmallestUnit)
+	assert.False(t, clamped)
+	assert.Equal(t, Max, f)
+
+	f, clamped = Max.SaturatingAdd(SmallestUnit)
+	assert.True(t, clamped)
+	assert.Equal(t, Max, f)
+
+	f, clamped = Max.SaturatingAdd(Max)
+	assert.True(t, clamped)
+	assert.Equal(t, Max, f)
+}
+
+func TestSaturatingSub(t *testing.T) {
+	f, clamped := MustParse("3").SaturatingSub(MustParse("1.25"))
+	assert.False(t, clamped)
+	assert.Equal(t, "1.75", f.String())
+
+	f, clamped = MustParse("3").SaturatingSub(MustParse("3"))
+	assert.False(t, clamped)
+	assert.Equal(t, Zero, f)
+
+	f, clamped = MustParse("99").SaturatingSub(MustParse("100"))
+	assert.True(t, clamped)
+	assert.Equal(t, Zero, f)
+
+	f, clamped = Zero.SaturatingSub(SmallestUnit)
+	assert.True(t, clamped)
+	assert.Equal(t, Zero, f)
+}
+
+func TestSaturatingMul(t *testing.T) {
+	f, clamped := MustParse("123.456").SaturatingMul(MustParse("1000"))
+	assert.False(t, clamped)
+	assert.Equal(t, "123456", f.String())
+
+	f, clamped = MustParse("50000000000").SaturatingMul(MustParse("2"))
+	assert.True(t, clamped)
+	assert.Equal(t, Max, f)
+
+	f, clamped = Max.SaturatingMul(Max)
+	assert.True(t, clamped)
+	assert.Equal(t, Max, f)
+}
+
+func TestSaturatingDiv(t *testing.T) {
+	f, clamped := MustParse("2").SaturatingDiv(MustParse("3"))
+	assert.False(t, clamped)
+	assert.Equal(t, "0.66666667", f.String())
+
+	f, clamped = Max.SaturatingDiv(MustParse("0.5"))
+	assert.True(t, clamped)
+	assert.Equal(t, Max, f)
+
+	f, clamped = MustParse("1").SaturatingDiv(Zero)
+	assert.True(t, clamped)
+	assert.Equal(t, Max, f)
+}
+
+func TestSaturatingAllocs(t *testing.T) {
+	f0 := MustParse("1234.5678")
+	f1 := MustParse("0.0001")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		f0.SaturatingAdd(f1)
+		f1.SaturatingSub(f0)
+		f0.SaturatingMul(Max)
+		f0.SaturatingDiv(Zero)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
@@ -0,0 +1,22 @@
+package udecimal
+
+//go:generate go run gen_scaled.go
+
+// Decimal8 is a decimal with a fixed 8 decimal places. It is the same type as Decimal, and is declared
+// alongside the generated Decimal2, Decimal4 and Decimal6 types for symmetry.
+type Decimal8 = Decimal
+
+// rescaleUnits converts a fixed point value from one number of decimal places to another, rounding
+// according to mode when places are dropped
+func rescaleUnits(fp uint64, from, to int, mode RoundMode) (uint64, error) {
+	if to >= from {
+		m := pow10[to-from]
+		if fp > maxFP/m {
+			return 0, ErrOverflow
+		}
+		return fp * m, nil
+	}
+
+	// the quotient is rounded in the target units, so rounding up to a larger power of 10 still fits
+	return quoUnits(0, fp, pow10[from-to], mode)
+}
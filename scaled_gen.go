@@ -0,0 +1,867 @@
+// Code generated by gen_scaled.go; DO NOT EDIT.
+
+package udecimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Decimal2 is a decimal with a fixed 2 decimal places. The maximum permitted value is 99999999999999999.99.
+type Decimal2 struct {
+	fp uint64
+}
+
+const places2 = 2
+const unit2 = uint64(100)
+
+var Zero2 = Decimal2{fp: 0}
+
+// Max2 is the largest representable Decimal2
+var Max2 = Decimal2{fp: maxFP}
+
+// ParseDecimal2 creates a Decimal2 from a string, truncating fractional digits beyond 2 decimal places
+func ParseDecimal2(s string) (Decimal2, error) {
+	fp, err := parseUnits(s, places2)
+	if err != nil {
+		return Zero2, err
+	}
+	return Decimal2{fp: fp}, nil
+}
+
+// MustParseDecimal2 creates a new Decimal2 from a string, and panics if the string could not be parsed
+func MustParseDecimal2(s string) Decimal2 {
+	f, err := ParseDecimal2(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseStrictDecimal2 creates a Decimal2 from a string like ParseStrict, rejecting more than 2
+// significant fractional digits
+func ParseStrictDecimal2(s string) (Decimal2, error) {
+	fp, err := parseStrictUnits(s, places2)
+	if err != nil {
+		return Zero2, err
+	}
+	return Decimal2{fp: fp}, nil
+}
+
+func (f Decimal2) IsZero() bool {
+	return f.fp == 0
+}
+
+// Float converts the Decimal2 to a float64
+func (f Decimal2) Float() float64 {
+	return float64(f.fp) / float64(unit2)
+}
+
+// Add adds f0 to f producing a Decimal2.
+func (f Decimal2) Add(f0 Decimal2) Decimal2 {
+	fp, err := addUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal2{fp: fp}
+}
+
+// Sub subtracts f0 from f producing a Decimal2.
+func (f Decimal2) Sub(f0 Decimal2) Decimal2 {
+	fp, err := subUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal2{fp: fp}
+}
+
+// Mul multiplies f by f0 returning a Decimal2, truncating to 2 decimal places.
+func (f Decimal2) Mul(f0 Decimal2) Decimal2 {
+	return f.MulRound(f0, RoundDown)
+}
+
+// MulRound multiplies f by f0 like Mul, rounding the exact product to 2 decimal places using the
+// specified rounding mode.
+func (f Decimal2) MulRound(f0 Decimal2, mode RoundMode) Decimal2 {
+	fp, err := mulUnits(f.fp, f0.fp, unit2, mode)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal2{fp: fp}
+}
+
+// Div divides f by f0 returning a Decimal2, rounded half-up to 2 decimal places.
+// Div panics if f0 is zero or if the result overflows.
+func (f Decimal2) Div(f0 Decimal2) Decimal2 {
+	fp, err := divUnits(f.fp, f0.fp, unit2, RoundHalfUp)
+	if err != nil {
+		if err == ErrDivisionByZero {
+			panic("decimal division by zero")
+		}
+		panic("decimal overflow")
+	}
+	return Decimal2{fp: fp}
+}
+
+// Round returns f rounded (half-up, away from zero) to n decimal places
+func (f Decimal2) Round(n int) Decimal2 {
+	return f.RoundWith(n, RoundHalfUp)
+}
+
+// RoundWith returns f rounded to n decimal places using the specified rounding mode
+func (f Decimal2) RoundWith(n int, mode RoundMode) Decimal2 {
+	fp, ok := roundUnits(f.fp, places2, n, mode)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return Decimal2{fp: fp}
+}
+
+// Equal returns true if the f == f0.
+func (f Decimal2) Equal(f0 Decimal2) bool {
+	return f.fp == f0.fp
+}
+
+// GreaterThan returns true if the f > f0.
+func (f Decimal2) GreaterThan(f0 Decimal2) bool {
+	return f.fp > f0.fp
+}
+
+// GreaterThanOrEqual returns true if the f >= f0.
+func (f Decimal2) GreaterThanOrEqual(f0 Decimal2) bool {
+	return f.fp >= f0.fp
+}
+
+// LessThan returns true if the f < f0.
+func (f Decimal2) LessThan(f0 Decimal2) bool {
+	return f.fp < f0.fp
+}
+
+// LessThanOrEqual returns true if the f <= f0.
+func (f Decimal2) LessThanOrEqual(f0 Decimal2) bool {
+	return f.fp <= f0.fp
+}
+
+// Cmp compares two Decimal2. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1.
+func (f Decimal2) Cmp(f0 Decimal2) int {
+	if f.fp == f0.fp {
+		return 0
+	}
+	if f.fp < f0.fp {
+		return -1
+	}
+	return 1
+}
+
+// String converts a Decimal2 to a string, dropping trailing zeros
+func (f Decimal2) String() string {
+	var buffer [24]byte
+	return string(f.AppendString(buffer[:0]))
+}
+
+// StringN converts a Decimal2 to a String with a specified number of decimal places, truncating as required
+func (f Decimal2) StringN(decimals int) string {
+	var buffer [32]byte
+	return string(f.AppendStringN(buffer[:0], decimals))
+}
+
+// AppendString appends the string form of f, as returned by String, to dst and returns the extended buffer
+func (f Decimal2) AppendString(dst []byte) []byte {
+	return appendUnits(dst, f.fp, places2)
+}
+
+// AppendStringN appends f with the specified number of decimal places, as returned by StringN, to dst and
+// returns the extended buffer
+func (f Decimal2) AppendStringN(dst []byte, decimals int) []byte {
+	return appendUnitsN(dst, f.fp, places2, decimals)
+}
+
+// AppendText implements the encoding.TextAppender interface, appending the string form of f to b
+func (f Decimal2) AppendText(b []byte) ([]byte, error) {
+	return f.AppendString(b), nil
+}
+
+// Format implements the fmt.Formatter interface. See Decimal.Format for the supported verbs.
+func (f Decimal2) Format(s fmt.State, verb rune) {
+	var buffer [24]byte
+	formatDecimal(s, verb, "udecimal.Decimal2", false, f.AppendString(buffer[:0]))
+}
+
+// Int return the integer portion of the Decimal2
+func (f Decimal2) Int() uint64 {
+	return f.fp / unit2
+}
+
+// ToDecimal4 converts f to a Decimal4, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal2) ToDecimal4(mode RoundMode) (Decimal4, error) {
+	fp, err := rescaleUnits(f.fp, places2, 4, mode)
+	return Decimal4{fp: fp}, err
+}
+
+// ToDecimal6 converts f to a Decimal6, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal2) ToDecimal6(mode RoundMode) (Decimal6, error) {
+	fp, err := rescaleUnits(f.fp, places2, 6, mode)
+	return Decimal6{fp: fp}, err
+}
+
+// ToDecimal converts f to a Decimal, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal2) ToDecimal(mode RoundMode) (Decimal, error) {
+	fp, err := rescaleUnits(f.fp, places2, 8, mode)
+	return Decimal{fp: fp}, err
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (f *Decimal2) UnmarshalBinary(data []byte) error {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errFormat
+	}
+	f.fp = fp
+	return nil
+}
+
+// UnmarshalBinaryData Unmarshals data and returns n
+func (f *Decimal2) UnmarshalBinaryData(data []byte) (rem []byte, err error) {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return data, errFormat
+	}
+	f.fp = fp
+	return data[n:], nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f Decimal2) MarshalBinary() (data []byte, err error) {
+	var buffer [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buffer[:], f.fp)
+	return buffer[:n], nil
+}
+
+// WriteTo write the Decimal2 to an io.Writer, returning the number of bytes written
+func (f Decimal2) WriteTo(w io.ByteWriter) error {
+	return writeUvarint(w, f.fp)
+}
+
+// ReadDecimal2From reads a Decimal2 from an io.Reader
+func ReadDecimal2From(r io.ByteReader) (Decimal2, error) {
+	fp, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Zero2, err
+	}
+	return Decimal2{fp: fp}, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (f *Decimal2) UnmarshalText(text []byte) error {
+	decimal, err := ParseDecimal2(string(text))
+	*f = decimal
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the String form of f
+func (f Decimal2) MarshalText() ([]byte, error) {
+	return f.AppendString(nil), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both JSON numbers and strings are accepted.
+func (f *Decimal2) UnmarshalJSON(bytes []byte) error {
+	s := string(bytes)
+	if s == "null" {
+		return nil
+	}
+
+	decimal, err := ParseDecimal2(unquoteJSON(s))
+	*f = decimal
+	if err != nil {
+		return fmt.Errorf("Error decoding string '%s': %s", s, err)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f Decimal2) MarshalJSON() ([]byte, error) {
+	return f.AppendStringN(make([]byte, 0, 24), places2), nil
+}
+
+// Decimal4 is a decimal with a fixed 4 decimal places. The maximum permitted value is 999999999999999.9999.
+type Decimal4 struct {
+	fp uint64
+}
+
+const places4 = 4
+const unit4 = uint64(10000)
+
+var Zero4 = Decimal4{fp: 0}
+
+// Max4 is the largest representable Decimal4
+var Max4 = Decimal4{fp: maxFP}
+
+// ParseDecimal4 creates a Decimal4 from a string, truncating fractional digits beyond 4 decimal places
+func ParseDecimal4(s string) (Decimal4, error) {
+	fp, err := parseUnits(s, places4)
+	if err != nil {
+		return Zero4, err
+	}
+	return Decimal4{fp: fp}, nil
+}
+
+// MustParseDecimal4 creates a new Decimal4 from a string, and panics if the string could not be parsed
+func MustParseDecimal4(s string) Decimal4 {
+	f, err := ParseDecimal4(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseStrictDecimal4 creates a Decimal4 from a string like ParseStrict, rejecting more than 4
+// significant fractional digits
+func ParseStrictDecimal4(s string) (Decimal4, error) {
+	fp, err := parseStrictUnits(s, places4)
+	if err != nil {
+		return Zero4, err
+	}
+	return Decimal4{fp: fp}, nil
+}
+
+func (f Decimal4) IsZero() bool {
+	return f.fp == 0
+}
+
+// Float converts the Decimal4 to a float64
+func (f Decimal4) Float() float64 {
+	return float64(f.fp) / float64(unit4)
+}
+
+// Add adds f0 to f producing a Decimal4.
+func (f Decimal4) Add(f0 Decimal4) Decimal4 {
+	fp, err := addUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal4{fp: fp}
+}
+
+// Sub subtracts f0 from f producing a Decimal4.
+func (f Decimal4) Sub(f0 Decimal4) Decimal4 {
+	fp, err := subUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal4{fp: fp}
+}
+
+// Mul multiplies f by f0 returning a Decimal4, truncating to 4 decimal places.
+func (f Decimal4) Mul(f0 Decimal4) Decimal4 {
+	return f.MulRound(f0, RoundDown)
+}
+
+// MulRound multiplies f by f0 like Mul, rounding the exact product to 4 decimal places using the
+// specified rounding mode.
+func (f Decimal4) MulRound(f0 Decimal4, mode RoundMode) Decimal4 {
+	fp, err := mulUnits(f.fp, f0.fp, unit4, mode)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal4{fp: fp}
+}
+
+// Div divides f by f0 returning a Decimal4, rounded half-up to 4 decimal places.
+// Div panics if f0 is zero or if the result overflows.
+func (f Decimal4) Div(f0 Decimal4) Decimal4 {
+	fp, err := divUnits(f.fp, f0.fp, unit4, RoundHalfUp)
+	if err != nil {
+		if err == ErrDivisionByZero {
+			panic("decimal division by zero")
+		}
+		panic("decimal overflow")
+	}
+	return Decimal4{fp: fp}
+}
+
+// Round returns f rounded (half-up, away from zero) to n decimal places
+func (f Decimal4) Round(n int) Decimal4 {
+	return f.RoundWith(n, RoundHalfUp)
+}
+
+// RoundWith returns f rounded to n decimal places using the specified rounding mode
+func (f Decimal4) RoundWith(n int, mode RoundMode) Decimal4 {
+	fp, ok := roundUnits(f.fp, places4, n, mode)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return Decimal4{fp: fp}
+}
+
+// Equal returns true if the f == f0.
+func (f Decimal4) Equal(f0 Decimal4) bool {
+	return f.fp == f0.fp
+}
+
+// GreaterThan returns true if the f > f0.
+func (f Decimal4) GreaterThan(f0 Decimal4) bool {
+	return f.fp > f0.fp
+}
+
+// GreaterThanOrEqual returns true if the f >= f0.
+func (f Decimal4) GreaterThanOrEqual(f0 Decimal4) bool {
+	return f.fp >= f0.fp
+}
+
+// LessThan returns true if the f < f0.
+func (f Decimal4) LessThan(f0 Decimal4) bool {
+	return f.fp < f0.fp
+}
+
+// LessThanOrEqual returns true if the f <= f0.
+func (f Decimal4) LessThanOrEqual(f0 Decimal4) bool {
+	return f.fp <= f0.fp
+}
+
+// Cmp compares two Decimal4. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1.
+func (f Decimal4) Cmp(f0 Decimal4) int {
+	if f.fp == f0.fp {
+		return 0
+	}
+	if f.fp < f0.fp {
+		return -1
+	}
+	return 1
+}
+
+// String converts a Decimal4 to a string, dropping trailing zeros
+func (f Decimal4) String() string {
+	var buffer [24]byte
+	return string(f.AppendString(buffer[:0]))
+}
+
+// StringN converts a Decimal4 to a String with a specified number of decimal places, truncating as required
+func (f Decimal4) StringN(decimals int) string {
+	var buffer [32]byte
+	return string(f.AppendStringN(buffer[:0], decimals))
+}
+
+// AppendString appends the string form of f, as returned by String, to dst and returns the extended buffer
+func (f Decimal4) AppendString(dst []byte) []byte {
+	return appendUnits(dst, f.fp, places4)
+}
+
+// AppendStringN appends f with the specified number of decimal places, as returned by StringN, to dst and
+// returns the extended buffer
+func (f Decimal4) AppendStringN(dst []byte, decimals int) []byte {
+	return appendUnitsN(dst, f.fp, places4, decimals)
+}
+
+// AppendText implements the encoding.TextAppender interface, appending the string form of f to b
+func (f Decimal4) AppendText(b []byte) ([]byte, error) {
+	return f.AppendString(b), nil
+}
+
+// Format implements the fmt.Formatter interface. See Decimal.Format for the supported verbs.
+func (f Decimal4) Format(s fmt.State, verb rune) {
+	var buffer [24]byte
+	formatDecimal(s, verb, "udecimal.Decimal4", false, f.AppendString(buffer[:0]))
+}
+
+// Int return the integer portion of the Decimal4
+func (f Decimal4) Int() uint64 {
+	return f.fp / unit4
+}
+
+// ToDecimal2 converts f to a Decimal2, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal4) ToDecimal2(mode RoundMode) (Decimal2, error) {
+	fp, err := rescaleUnits(f.fp, places4, 2, mode)
+	return Decimal2{fp: fp}, err
+}
+
+// ToDecimal6 converts f to a Decimal6, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal4) ToDecimal6(mode RoundMode) (Decimal6, error) {
+	fp, err := rescaleUnits(f.fp, places4, 6, mode)
+	return Decimal6{fp: fp}, err
+}
+
+// ToDecimal converts f to a Decimal, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal4) ToDecimal(mode RoundMode) (Decimal, error) {
+	fp, err := rescaleUnits(f.fp, places4, 8, mode)
+	return Decimal{fp: fp}, err
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (f *Decimal4) UnmarshalBinary(data []byte) error {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errFormat
+	}
+	f.fp = fp
+	return nil
+}
+
+// UnmarshalBinaryData Unmarshals data and returns n
+func (f *Decimal4) UnmarshalBinaryData(data []byte) (rem []byte, err error) {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return data, errFormat
+	}
+	f.fp = fp
+	return data[n:], nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f Decimal4) MarshalBinary() (data []byte, err error) {
+	var buffer [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buffer[:], f.fp)
+	return buffer[:n], nil
+}
+
+// WriteTo write the Decimal4 to an io.Writer, returning the number of bytes written
+func (f Decimal4) WriteTo(w io.ByteWriter) error {
+	return writeUvarint(w, f.fp)
+}
+
+// ReadDecimal4From reads a Decimal4 from an io.Reader
+func ReadDecimal4From(r io.ByteReader) (Decimal4, error) {
+	fp, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Zero4, err
+	}
+	return Decimal4{fp: fp}, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (f *Decimal4) UnmarshalText(text []byte) error {
+	decimal, err := ParseDecimal4(string(text))
+	*f = decimal
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the String form of f
+func (f Decimal4) MarshalText() ([]byte, error) {
+	return f.AppendString(nil), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both JSON numbers and strings are accepted.
+func (f *Decimal4) UnmarshalJSON(bytes []byte) error {
+	s := string(bytes)
+	if s == "null" {
+		return nil
+	}
+
+	decimal, err := ParseDecimal4(unquoteJSON(s))
+	*f = decimal
+	if err != nil {
+		return fmt.Errorf("Error decoding string '%s': %s", s, err)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f Decimal4) MarshalJSON() ([]byte, error) {
+	return f.AppendStringN(make([]byte, 0, 24), places4), nil
+}
+
+// Decimal6 is a decimal with a fixed 6 decimal places. The maximum permitted value is 9999999999999.999999.
+type Decimal6 struct {
+	fp uint64
+}
+
+const places6 = 6
+const unit6 = uint64(1000000)
+
+var Zero6 = Decimal6{fp: 0}
+
+// Max6 is the largest representable Decimal6
+var Max6 = Decimal6{fp: maxFP}
+
+// ParseDecimal6 creates a Decimal6 from a string, truncating fractional digits beyond 6 decimal places
+func ParseDecimal6(s string) (Decimal6, error) {
+	fp, err := parseUnits(s, places6)
+	if err != nil {
+		return Zero6, err
+	}
+	return Decimal6{fp: fp}, nil
+}
+
+// MustParseDecimal6 creates a new Decimal6 from a string, and panics if the string could not be parsed
+func MustParseDecimal6(s string) Decimal6 {
+	f, err := ParseDecimal6(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseStrictDecimal6 creates a Decimal6 from a string like ParseStrict, rejecting more than 6
+// significant fractional digits
+func ParseStrictDecimal6(s string) (Decimal6, error) {
+	fp, err := parseStrictUnits(s, places6)
+	if err != nil {
+		return Zero6, err
+	}
+	return Decimal6{fp: fp}, nil
+}
+
+func (f Decimal6) IsZero() bool {
+	return f.fp == 0
+}
+
+// Float converts the Decimal6 to a float64
+func (f Decimal6) Float() float64 {
+	return float64(f.fp) / float64(unit6)
+}
+
+// Add adds f0 to f producing a Decimal6.
+func (f Decimal6) Add(f0 Decimal6) Decimal6 {
+	fp, err := addUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal6{fp: fp}
+}
+
+// Sub subtracts f0 from f producing a Decimal6.
+func (f Decimal6) Sub(f0 Decimal6) Decimal6 {
+	fp, err := subUnits(f.fp, f0.fp)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal6{fp: fp}
+}
+
+// Mul multiplies f by f0 returning a Decimal6, truncating to 6 decimal places.
+func (f Decimal6) Mul(f0 Decimal6) Decimal6 {
+	return f.MulRound(f0, RoundDown)
+}
+
+// MulRound multiplies f by f0 like Mul, rounding the exact product to 6 decimal places using the
+// specified rounding mode.
+func (f Decimal6) MulRound(f0 Decimal6, mode RoundMode) Decimal6 {
+	fp, err := mulUnits(f.fp, f0.fp, unit6, mode)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return Decimal6{fp: fp}
+}
+
+// Div divides f by f0 returning a Decimal6, rounded half-up to 6 decimal places.
+// Div panics if f0 is zero or if the result overflows.
+func (f Decimal6) Div(f0 Decimal6) Decimal6 {
+	fp, err := divUnits(f.fp, f0.fp, unit6, RoundHalfUp)
+	if err != nil {
+		if err == ErrDivisionByZero {
+			panic("decimal division by zero")
+		}
+		panic("decimal overflow")
+	}
+	return Decimal6{fp: fp}
+}
+
+// Round returns f rounded (half-up, away from zero) to n decimal places
+func (f Decimal6) Round(n int) Decimal6 {
+	return f.RoundWith(n, RoundHalfUp)
+}
+
+// RoundWith returns f rounded to n decimal places using the specified rounding mode
+func (f Decimal6) RoundWith(n int, mode RoundMode) Decimal6 {
+	fp, ok := roundUnits(f.fp, places6, n, mode)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return Decimal6{fp: fp}
+}
+
+// Equal returns true if the f == f0.
+func (f Decimal6) Equal(f0 Decimal6) bool {
+	return f.fp == f0.fp
+}
+
+// GreaterThan returns true if the f > f0.
+func (f Decimal6) GreaterThan(f0 Decimal6) bool {
+	return f.fp > f0.fp
+}
+
+// GreaterThanOrEqual returns true if the f >= f0.
+func (f Decimal6) GreaterThanOrEqual(f0 Decimal6) bool {
+	return f.fp >= f0.fp
+}
+
+// LessThan returns true if the f < f0.
+func (f Decimal6) LessThan(f0 Decimal6) bool {
+	return f.fp < f0.fp
+}
+
+// LessThanOrEqual returns true if the f <= f0.
+func (f Decimal6) LessThanOrEqual(f0 Decimal6) bool {
+	return f.fp <= f0.fp
+}
+
+// Cmp compares two Decimal6. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1.
+func (f Decimal6) Cmp(f0 Decimal6) int {
+	if f.fp == f0.fp {
+		return 0
+	}
+	if f.fp < f0.fp {
+		return -1
+	}
+	return 1
+}
+
+// String converts a Decimal6 to a string, dropping trailing zeros
+func (f Decimal6) String() string {
+	var buffer [24]byte
+	return string(f.AppendString(buffer[:0]))
+}
+
+// StringN converts a Decimal6 to a String with a specified number of decimal places, truncating as required
+func (f Decimal6) StringN(decimals int) string {
+	var buffer [32]byte
+	return string(f.AppendStringN(buffer[:0], decimals))
+}
+
+// AppendString appends the string form of f, as returned by String, to dst and returns the extended buffer
+func (f Decimal6) AppendString(dst []byte) []byte {
+	return appendUnits(dst, f.fp, places6)
+}
+
+// AppendStringN appends f with the specified number of decimal places, as returned by StringN, to dst and
+// returns the extended buffer
+func (f Decimal6) AppendStringN(dst []byte, decimals int) []byte {
+	return appendUnitsN(dst, f.fp, places6, decimals)
+}
+
+// AppendText implements the encoding.TextAppender interface, appending the string form of f to b
+func (f Decimal6) AppendText(b []byte) ([]byte, error) {
+	return f.AppendString(b), nil
+}
+
+// Format implements the fmt.Formatter interface. See Decimal.Format for the supported verbs.
+func (f Decimal6) Format(s fmt.State, verb rune) {
+	var buffer [24]byte
+	formatDecimal(s, verb, "udecimal.Decimal6", false, f.AppendString(buffer[:0]))
+}
+
+// Int return the integer portion of the Decimal6
+func (f Decimal6) Int() uint64 {
+	return f.fp / unit6
+}
+
+// ToDecimal2 converts f to a Decimal2, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal6) ToDecimal2(mode RoundMode) (Decimal2, error) {
+	fp, err := rescaleUnits(f.fp, places6, 2, mode)
+	return Decimal2{fp: fp}, err
+}
+
+// ToDecimal4 converts f to a Decimal4, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal6) ToDecimal4(mode RoundMode) (Decimal4, error) {
+	fp, err := rescaleUnits(f.fp, places6, 4, mode)
+	return Decimal4{fp: fp}, err
+}
+
+// ToDecimal converts f to a Decimal, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal6) ToDecimal(mode RoundMode) (Decimal, error) {
+	fp, err := rescaleUnits(f.fp, places6, 8, mode)
+	return Decimal{fp: fp}, err
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (f *Decimal6) UnmarshalBinary(data []byte) error {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errFormat
+	}
+	f.fp = fp
+	return nil
+}
+
+// UnmarshalBinaryData Unmarshals data and returns n
+func (f *Decimal6) UnmarshalBinaryData(data []byte) (rem []byte, err error) {
+	fp, n := binary.Uvarint(data)
+	if n <= 0 {
+		return data, errFormat
+	}
+	f.fp = fp
+	return data[n:], nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (f Decimal6) MarshalBinary() (data []byte, err error) {
+	var buffer [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buffer[:], f.fp)
+	return buffer[:n], nil
+}
+
+// WriteTo write the Decimal6 to an io.Writer, returning the number of bytes written
+func (f Decimal6) WriteTo(w io.ByteWriter) error {
+	return writeUvarint(w, f.fp)
+}
+
+// ReadDecimal6From reads a Decimal6 from an io.Reader
+func ReadDecimal6From(r io.ByteReader) (Decimal6, error) {
+	fp, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Zero6, err
+	}
+	return Decimal6{fp: fp}, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (f *Decimal6) UnmarshalText(text []byte) error {
+	decimal, err := ParseDecimal6(string(text))
+	*f = decimal
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the String form of f
+func (f Decimal6) MarshalText() ([]byte, error) {
+	return f.AppendString(nil), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both JSON numbers and strings are accepted.
+func (f *Decimal6) UnmarshalJSON(bytes []byte) error {
+	s := string(bytes)
+	if s == "null" {
+		return nil
+	}
+
+	decimal, err := ParseDecimal6(unquoteJSON(s))
+	*f = decimal
+	if err != nil {
+		return fmt.Errorf("Error decoding string '%s': %s", s, err)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f Decimal6) MarshalJSON() ([]byte, error) {
+	return f.AppendStringN(make([]byte, 0, 24), places6), nil
+}
+
+// ToDecimal2 converts f to a Decimal2, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal) ToDecimal2(mode RoundMode) (Decimal2, error) {
+	fp, err := rescaleUnits(f.fp, nPlaces, 2, mode)
+	return Decimal2{fp: fp}, err
+}
+
+// ToDecimal4 converts f to a Decimal4, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal) ToDecimal4(mode RoundMode) (Decimal4, error) {
+	fp, err := rescaleUnits(f.fp, nPlaces, 4, mode)
+	return Decimal4{fp: fp}, err
+}
+
+// ToDecimal6 converts f to a Decimal6, rounding according to mode if places are dropped. It returns
+// ErrOverflow if the result is too large.
+func (f Decimal) ToDecimal6(mode RoundMode) (Decimal6, error) {
+	fp, err := rescaleUnits(f.fp, nPlaces, 6, mode)
+	return Decimal6{fp: fp}, err
+}
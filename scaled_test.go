@@ -0,0 +1,212 @@
+package udecimal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaledParse(t *testing.T) {
+	assert.Equal(t, "123.45", MustParseDecimal2("123.456").String())
+	assert.Equal(t, "123.456", MustParseDecimal4("123.456").String())
+	assert.Equal(t, "123.456", MustParseDecimal6("123.456").String())
+
+	assert.Equal(t, "99999999999999999.99", Max2.String())
+	assert.Equal(t, "999999999999999.9999", Max4.String())
+	assert.Equal(t, "9999999999999.999999", Max6.String())
+	assert.Equal(t, Max2, MustParseDecimal2("99999999999999999.99"))
+
+	_, err := ParseDecimal2("100000000000000000")
+	assert.Error(t, err)
+	_, err = ParseDecimal4("abc")
+	assert.Error(t, err)
+	assert.Panics(t, func() { MustParseDecimal6("1,5") })
+
+	assert.Equal(t, uint64(123), MustParseDecimal4("123.4567").Int())
+	assert.Equal(t, 123.4567, MustParseDecimal4("123.4567").Float())
+	assert.True(t, Zero6.IsZero())
+}
+
+func TestScaledArithmetic(t *testing.T) {
+	a := MustParseDecimal2("10.05")
+	b := MustParseDecimal2("3")
+
+	assert.Equal(t, "13.05", a.Add(b).String())
+	assert.Equal(t, "7.05", a.Sub(b).String())
+	assert.Equal(t, "30.15", a.Mul(b).String())
+	assert.Equal(t, "3.35", a.Div(b).String())
+	assert.Equal(t, "0.14", MustParseDecimal2("0.15").Mul(MustParseDecimal2("0.99")).String())
+	assert.Equal(t, "0.15", MustParseDecimal2("0.15").MulRound(MustParseDecimal2("0.99"), RoundHalfEven).String())
+	assert.Equal(t, "0.67", MustParseDecimal2("2").Div(b).String())
+
+	assert.Panics(t, func() { Max2.Add(MustParseDecimal2("0.01")) })
+	assert.Panics(t, func() { b.Sub(a) })
+	assert.Panics(t, func() { Max2.Mul(b) })
+	assert.Panics(t, func() { a.Div(Zero2) })
+
+	c := MustParseDecimal4("1.2345")
+	assert.Equal(t, "1.235", c.Round(3).String())
+	assert.Equal(t, "1.234", c.RoundWith(3, RoundHalfEven).String())
+	assert.Equal(t, "0.0001", MustParseDecimal4("0.0002").Div(MustParseDecimal4("3")).String())
+
+	d := MustParseDecimal6("0.000001")
+	assert.Equal(t, "0.000001", d.Mul(MustParseDecimal6("1.5")).String())
+	assert.Equal(t, "0.000002", d.Add(d).String())
+}
+
+func TestScaledCompare(t *testing.T) {
+	a := MustParseDecimal4("1.5")
+	b := MustParseDecimal4("2")
+
+	assert.True(t, a.LessThan(b))
+	assert.True(t, a.LessThanOrEqual(a))
+	assert.True(t, b.GreaterThan(a))
+	assert.True(t, b.GreaterThanOrEqual(b))
+	assert.True(t, a.Equal(MustParseDecimal4("1.50")))
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(a))
+}
+
+func TestScaledStringN(t *testing.T) {
+	assert.Equal(t, "1.10", MustParseDecimal2("1.1").StringN(2))
+	assert.Equal(t, "1", MustParseDecimal2("1.1").StringN(0))
+	assert.Equal(t, "0.0000", Zero4.StringN(4))
+	assert.Equal(t, "0.12", MustParseDecimal6("0.123456").StringN(2))
+}
+
+func TestScaledRescale(t *testing.T) {
+	d, err := MustParse("1.23456789").ToDecimal2(RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.23", d.String())
+
+	d, err = MustParse("1.235").ToDecimal2(RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.24", d.String())
+
+	d, err = MustParse("1.225").ToDecimal2(RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.22", d.String())
+
+	d4, err := MustParse("1.00005").ToDecimal4(RoundCeiling)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0001", d4.String())
+
+	d6, err := MustParseDecimal2("123.45").ToDecimal6(RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "123.45", d6.String())
+
+	f, err := MustParseDecimal6("0.1234565").ToDecimal(RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.123456", f.String())
+
+	d2, err := MustParseDecimal4("0.0050").ToDecimal2(RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.01", d2.String())
+
+	d2, err = MustParseDecimal4("0.0050").ToDecimal2(RoundHalfDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", d2.String())
+
+	_, err = MustParseDecimal2("100000000000").ToDecimal(RoundDown)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	// rounding Max up to fewer places gives the next power of 10, which fits
+	for _, mode := range []RoundMode{RoundUp, RoundCeiling, RoundHalfUp, RoundHalfDown, RoundHalfEven} {
+		d2, err = Max.ToDecimal2(mode)
+		if assert.NoError(t, err, "%v", mode) {
+			assert.Equal(t, "100000000000", d2.String(), "%v", mode)
+		}
+		d4, err = Max.ToDecimal4(mode)
+		if assert.NoError(t, err, "%v", mode) {
+			assert.Equal(t, "100000000000", d4.String(), "%v", mode)
+		}
+		d6, err = Max.ToDecimal6(mode)
+		if assert.NoError(t, err, "%v", mode) {
+			assert.Equal(t, "100000000000", d6.String(), "%v", mode)
+		}
+		d2, err = Max4.ToDecimal2(mode)
+		if assert.NoError(t, err, "%v", mode) {
+			assert.Equal(t, "1000000000000000", d2.String(), "%v", mode)
+		}
+		d4, err = Max6.ToDecimal4(mode)
+		if assert.NoError(t, err, "%v", mode) {
+			assert.Equal(t, "10000000000000", d4.String(), "%v", mode)
+		}
+	}
+
+	d2, err = Max4.ToDecimal2(RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "999999999999999.99", d2.String())
+
+	var d8 Decimal8 = MustParse("1.5")
+	d2, err = d8.ToDecimal2(RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", d2.String())
+}
+
+func TestScaledEncodeDecode(t *testing.T) {
+	f := MustParseDecimal4("12345.1234")
+
+	b := &bytes.Buffer{}
+	assert.NoError(t, f.WriteTo(b))
+	f0, err := ReadDecimal4From(b)
+	assert.NoError(t, err)
+	assert.Equal(t, f, f0)
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+	var f1 Decimal4
+	assert.NoError(t, f1.UnmarshalBinary(data))
+	assert.Equal(t, f, f1)
+
+	rem, err := f1.UnmarshalBinaryData(append(data, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{3}, rem)
+}
+
+type ScaledStruct struct {
+	Cash  Decimal2 `json:"cash"`
+	Rate  Decimal4 `json:"rate"`
+	Price Decimal6 `json:"price"`
+	Qty   Decimal8 `json:"qty"`
+}
+
+func TestScaledJSON(t *testing.T) {
+	s := ScaledStruct{
+		Cash:  MustParseDecimal2("100.25"),
+		Rate:  MustParseDecimal4("1.0825"),
+		Price: MustParseDecimal6("0.000123"),
+		Qty:   MustParse("0.00000001"),
+	}
+
+	data, err := json.Marshal(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"cash":100.25,"rate":1.0825,"price":0.000123,"qty":0.00000001}`, string(data))
+
+	var s0 ScaledStruct
+	assert.NoError(t, json.Unmarshal(data, &s0))
+	assert.Equal(t, s, s0)
+	assert.NoError(t, json.Unmarshal([]byte(`{"cash":"100.25","rate":"1.0825","price":"0.000123","qty":"0.00000001"}`), &s0))
+	assert.Equal(t, s, s0)
+
+	m := map[Decimal2]bool{MustParseDecimal2("1.10"): true}
+	data, err = json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1.1":true}`, string(data))
+}
+
+func TestScaledAppendString(t *testing.T) {
+	buf := []byte("cash=")
+	assert.Equal(t, "cash=100.5", string(MustParseDecimal2("100.50").AppendString(buf)))
+	assert.Equal(t, "cash=100.50", string(MustParseDecimal2("100.50").AppendStringN(buf, 2)))
+	assert.Equal(t, "cash=100.5000", string(MustParseDecimal2("100.50").AppendStringN(buf, 4)))
+
+	b, err := MustParseDecimal4("0.0001").AppendText(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "cash=0.0001", string(b))
+}
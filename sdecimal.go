@@ -0,0 +1,370 @@
+package udecimal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SDecimal is a signed decimal with the same 8 decimal places as Decimal. The permitted range is
+// -92233720368.54775808 to 92233720368.54775807, which does not cover the largest Decimal values, so
+// conversions from Decimal return ErrOverflow for values above 92233720368.54775807.
+type SDecimal struct {
+	fp int64
+}
+
+var SZero = SDecimal{fp: 0}
+
+// ParseSigned creates an SDecimal from a string with an optional leading '-'
+func ParseSigned(s string) (SDecimal, error) {
+	neg := len(s) > 0 && s[0] == '-'
+	if neg {
+		s = s[1:]
+	}
+	f, err := Parse(s)
+	if err != nil {
+		return SZero, err
+	}
+	r, err := fromMagnitude(neg, f.fp)
+	if err != nil {
+		return SZero, errTooLarge
+	}
+	return r, nil
+}
+
+// MustParseSigned creates a new SDecimal from a string, and panics if the string could not be parsed
+func MustParseSigned(s string) SDecimal {
+	f, err := ParseSigned(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseSignedFloat creates an SDecimal from a float64, rounding at the 8th decimal place
+func ParseSignedFloat(f float64) (SDecimal, error) {
+	m, err := ParseFloat(math.Abs(f))
+	if err != nil {
+		return SZero, err
+	}
+	r, err := fromMagnitude(f < 0, m.fp)
+	if err != nil {
+		return SZero, errors.New("invalid input")
+	}
+	return r, nil
+}
+
+// fromMagnitude creates an SDecimal from a sign and an unsigned fixed point magnitude
+func fromMagnitude(neg bool, m uint64) (SDecimal, error) {
+	if neg {
+		if m > 1<<63 {
+			return SZero, ErrOverflow
+		}
+		return SDecimal{fp: -int64(m)}, nil
+	}
+	if m > math.MaxInt64 {
+		return SZero, ErrOverflow
+	}
+	return SDecimal{fp: int64(m)}, nil
+}
+
+func mustFromMagnitude(neg bool, m uint64) SDecimal {
+	r, err := fromMagnitude(neg, m)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return r
+}
+
+// magnitude returns the absolute fixed point value of f
+func (f SDecimal) magnitude() uint64 {
+	if f.fp < 0 {
+		return uint64(-f.fp)
+	}
+	return uint64(f.fp)
+}
+
+// Signed converts f to an SDecimal. The conversion is exact, and returns ErrOverflow if f is larger than
+// the maximum SDecimal.
+func (f Decimal) Signed() (SDecimal, error) {
+	return fromMagnitude(false, f.fp)
+}
+
+// SubSigned subtracts f0 from f producing an SDecimal, which is negative if f0 is larger than f. The result
+// is exact, and ErrOverflow is returned if it is outside the SDecimal range.
+func (f Decimal) SubSigned(f0 Decimal) (SDecimal, error) {
+	if f.fp >= f0.fp {
+		return fromMagnitude(false, f.fp-f0.fp)
+	}
+	return fromMagnitude(true, f0.fp-f.fp)
+}
+
+// Abs returns the absolute value of f as a Decimal
+func (f SDecimal) Abs() Decimal {
+	return Decimal{fp: f.magnitude()}
+}
+
+// Unsigned converts f to a Decimal, returning ErrNegative if f is negative
+func (f SDecimal) Unsigned() (Decimal, error) {
+	if f.fp < 0 {
+		return Zero, ErrNegative
+	}
+	return Decimal{fp: uint64(f.fp)}, nil
+}
+
+func (f SDecimal) IsZero() bool {
+	return f.fp == 0
+}
+
+// IsNegative returns true if f < 0
+func (f SDecimal) IsNegative() bool {
+	return f.fp < 0
+}
+
+// Sign returns -1 if f < 0, 0 if f == 0 and 1 if f > 0
+func (f SDecimal) Sign() int {
+	if f.fp < 0 {
+		return -1
+	}
+	if f.fp > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Neg returns -f, and panics if the result overflows
+func (f SDecimal) Neg() SDecimal {
+	if f.fp == math.MinInt64 {
+		panic("decimal overflow")
+	}
+	return SDecimal{fp: -f.fp}
+}
+
+// Float converts the SDecimal to a float64
+func (f SDecimal) Float() float64 {
+	return float64(f.fp) / float64(scale)
+}
+
+// Add adds f0 to f producing an SDecimal.
+func (f SDecimal) Add(f0 SDecimal) SDecimal {
+	r := f.fp + f0.fp
+	if (f.fp >= 0) == (f0.fp >= 0) && (r >= 0) != (f.fp >= 0) {
+		panic("decimal overflow")
+	}
+	return SDecimal{fp: r}
+}
+
+// Sub subtracts f0 from f producing an SDecimal.
+func (f SDecimal) Sub(f0 SDecimal) SDecimal {
+	r := f.fp - f0.fp
+	if (f.fp >= 0) != (f0.fp >= 0) && (r >= 0) != (f.fp >= 0) {
+		panic("decimal overflow")
+	}
+	return SDecimal{fp: r}
+}
+
+// Mul multiplies f by f0 returning an SDecimal, truncating towards zero at the 8th decimal place.
+func (f SDecimal) Mul(f0 SDecimal) SDecimal {
+	m, err := mulUnits(f.magnitude(), f0.magnitude(), scale, RoundDown)
+	if err != nil {
+		panic("decimal overflow")
+	}
+	return mustFromMagnitude((f.fp < 0) != (f0.fp < 0), m)
+}
+
+// Div divides f by f0 returning an SDecimal, rounded half away from zero at the 8th decimal place.
+// Div panics if f0 is zero or if the result overflows.
+func (f SDecimal) Div(f0 SDecimal) SDecimal {
+	m, err := divUnits(f.magnitude(), f0.magnitude(), scale, RoundHalfUp)
+	if err != nil {
+		if err == ErrDivisionByZero {
+			panic("decimal division by zero")
+		}
+		panic("decimal overflow")
+	}
+	return mustFromMagnitude((f.fp < 0) != (f0.fp < 0), m)
+}
+
+// Round returns f rounded (half away from zero) to n decimal places
+func (f SDecimal) Round(n int) SDecimal {
+	return f.RoundWith(n, RoundHalfUp)
+}
+
+// RoundWith returns f rounded to n decimal places using the specified rounding mode
+func (f SDecimal) RoundWith(n int, mode RoundMode) SDecimal {
+	if f.fp < 0 {
+		// the magnitude of a negative number moves the opposite way to the number itself
+		switch mode {
+		case RoundCeiling:
+			mode = RoundDown
+		case RoundFloor:
+			mode = RoundUp
+		}
+	}
+	m, ok := roundUnits(f.magnitude(), nPlaces, n, mode)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return mustFromMagnitude(f.fp < 0, m)
+}
+
+// Equal returns true if the f == f0.
+func (f SDecimal) Equal(f0 SDecimal) bool {
+	return f.fp == f0.fp
+}
+
+// GreaterThan returns true if the f > f0.
+func (f SDecimal) GreaterThan(f0 SDecimal) bool {
+	return f.fp > f0.fp
+}
+
+// GreaterThanOrEqual returns true if the f >= f0.
+func (f SDecimal) GreaterThanOrEqual(f0 SDecimal) bool {
+	return f.fp >= f0.fp
+}
+
+// LessThan returns true if the f < f0.
+func (f SDecimal) LessThan(f0 SDecimal) bool {
+	return f.fp < f0.fp
+}
+
+// LessThanOrEqual returns true if the f <= f0.
+func (f SDecimal) LessThanOrEqual(f0 SDecimal) bool {
+	return f.fp <= f0.fp
+}
+
+// Cmp compares two SDecimal. If f == f0, return 0. If f > f0, return 1. If f < f0, return -1.
+func (f SDecimal) Cmp(f0 SDecimal) int {
+	if f.fp == f0.fp {
+		return 0
+	}
+	if f.fp < f0.fp {
+		return -1
+	}
+	return 1
+}
+
+// String converts an SDecimal to a string, dropping trailing zeros
+func (f SDecimal) String() string {
+	var buffer [24]byte
+	return string(f.AppendString(buffer[:0]))
+}
+
+// StringN converts an SDecimal to a String with a specified number of decimal places, truncating as required
+func (f SDecimal) StringN(decimals int) string {
+	var buffer [32]byte
+	return string(f.AppendStringN(buffer[:0], decimals))
+}
+
+// AppendString appends the string form of f, as returned by String, to dst and returns the extended buffer
+func (f SDecimal) AppendString(dst []byte) []byte {
+	if f.fp < 0 {
+		dst = append(dst, '-')
+	}
+	return appendUnits(dst, f.magnitude(), nPlaces)
+}
+
+// AppendStringN appends f with the specified number of decimal places, as returned by StringN, to dst and
+// returns the extended buffer
+func (f SDecimal) AppendStringN(dst []byte, decimals int) []byte {
+	if f.fp < 0 {
+		dst = append(dst, '-')
+	}
+	return appendUnitsN(dst, f.magnitude(), nPlaces, decimals)
+}
+
+// AppendText implements the encoding.TextAppender interface, appending the string form of f to b
+func (f SDecimal) AppendText(b []byte) ([]byte, error) {
+	return f.AppendString(b), nil
+}
+
+// Format implements the fmt.Formatter interface. See Decimal.Format for the supported verbs.
+func (f SDecimal) Format(s fmt.State, verb rune) {
+	var buffer [24]byte
+	formatDecimal(s, verb, "udecimal.SDecimal", f.fp < 0, appendUnits(buffer[:0], f.magnitude(), nPlaces))
+}
+
+// Int return the integer portion of the SDecimal, truncated towards zero
+func (f SDecimal) Int() int64 {
+	return f.fp / int64(scale)
+}
+
+// Frac return the fractional portion of the SDecimal, which has the same sign as f
+func (f SDecimal) Frac() float64 {
+	return float64(f.fp%int64(scale)) / float64(scale)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (f *SDecimal) UnmarshalBinary(data []byte) error {
+	fp, n := binary.Varint(data)
+	if n <= 0 {
+		return errFormat
+	}
+	f.fp = fp
+	return nil
+}
+
+// UnmarshalBinaryData Unmarshals data and returns n
+func (f *SDecimal) UnmarshalBinaryData(data []byte) (rem []byte, err error) {
+	fp, n := binary.Varint(data)
+	if n <= 0 {
+		return data, errFormat
+	}
+	f.fp = fp
+	return data[n:], err
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface using a zigzag varint.
+func (f SDecimal) MarshalBinary() (data []byte, err error) {
+	var buffer [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buffer[:], f.fp)
+	return buffer[:n], nil
+}
+
+// WriteTo write the SDecimal to an io.Writer, returning the number of bytes written
+func (f SDecimal) WriteTo(w io.ByteWriter) error {
+	return writeVarint(w, f.fp)
+}
+
+// ReadSignedFrom reads an SDecimal from an io.Reader
+func ReadSignedFrom(r io.ByteReader) (SDecimal, error) {
+	fp, err := binary.ReadVarint(r)
+	if err != nil {
+		return SZero, err
+	}
+	return SDecimal{fp: fp}, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (f *SDecimal) UnmarshalText(text []byte) error {
+	decimal, err := ParseSigned(string(text))
+	*f = decimal
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the String form of f
+func (f SDecimal) MarshalText() ([]byte, error) {
+	return f.AppendString(nil), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both JSON numbers and strings are accepted.
+func (f *SDecimal) UnmarshalJSON(bytes []byte) error {
+	s := string(bytes)
+	if s == "null" {
+		return nil
+	}
+
+	decimal, err := ParseSigned(unquoteJSON(s))
+	*f = decimal
+	if err != nil {
+		return fmt.Errorf("Error decoding string '%s': %s", s, err)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f SDecimal) MarshalJSON() ([]byte, error) {
+	return f.AppendStringN(make([]byte, 0, 24), nPlaces), nil
+}
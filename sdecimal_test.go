@@ -0,0 +1,276 @@
+package udecimal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedParse(t *testing.T) {
+	f := MustParseSigned("-123.456")
+	assert.Equal(t, "-123.456", f.String())
+	assert.True(t, f.IsNegative())
+	assert.Equal(t, -1, f.Sign())
+	assert.Equal(t, int64(-123), f.Int())
+
+	f = MustParseSigned("123.456")
+	assert.Equal(t, "123.456", f.String())
+	assert.Equal(t, 1, f.Sign())
+
+	f = MustParseSigned("-0")
+	assert.True(t, f.IsZero())
+	assert.Equal(t, "0", f.String())
+	assert.Equal(t, 0, f.Sign())
+
+	f = MustParseSigned("-.5")
+	assert.Equal(t, "-0.5", f.String())
+
+	f = MustParseSigned("92233720368.54775807")
+	assert.Equal(t, "92233720368.54775807", f.String())
+	f = MustParseSigned("-92233720368.54775808")
+	assert.Equal(t, "-92233720368.54775808", f.String())
+
+	_, err := ParseSigned("92233720368.54775808")
+	assert.Error(t, err)
+	_, err = ParseSigned("-92233720368.54775809")
+	assert.Error(t, err)
+	_, err = ParseSigned("--1")
+	assert.Error(t, err)
+	_, err = ParseSigned("-")
+	assert.Error(t, err)
+	_, err = ParseSigned("abc")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustParseSigned("1-") })
+}
+
+func TestSignedParseFloat(t *testing.T) {
+	f, err := ParseSignedFloat(-1.0 / 3.0)
+	assert.NoError(t, err)
+	assert.Equal(t, "-0.33333333", f.String())
+
+	f, err = ParseSignedFloat(2.0 / 3.0)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.66666667", f.String())
+	assert.Equal(t, 0.66666667, f.Float())
+
+	_, err = ParseSignedFloat(-1e12)
+	assert.Error(t, err)
+}
+
+func TestSignedArithmetic(t *testing.T) {
+	a := MustParseSigned("1.5")
+	b := MustParseSigned("-2.25")
+
+	assert.Equal(t, "-0.75", a.Add(b).String())
+	assert.Equal(t, "3.75", a.Sub(b).String())
+	assert.Equal(t, "-3.75", b.Sub(a).String())
+	assert.Equal(t, "-3.375", a.Mul(b).String())
+	assert.Equal(t, "5.0625", b.Mul(b).String())
+	assert.Equal(t, "-0.66666667", a.Div(b).String())
+	assert.Equal(t, "2.25", b.Neg().String())
+
+	assert.Equal(t, "-0.00000001", MustParseSigned("-0.00000001").Mul(MustParseSigned("1.5")).String())
+	assert.Equal(t, "0", MustParseSigned("-0.00000001").Mul(MustParseSigned("0.5")).String())
+
+	max := MustParseSigned("92233720368.54775807")
+	min := MustParseSigned("-92233720368.54775808")
+	unit := MustParseSigned("0.00000001")
+
+	assert.Panics(t, func() { max.Add(unit) })
+	assert.Panics(t, func() { min.Sub(unit) })
+	assert.Panics(t, func() { max.Sub(unit.Neg()) })
+	assert.Panics(t, func() { min.Neg() })
+	assert.Panics(t, func() { max.Mul(MustParseSigned("-2")) })
+	assert.Panics(t, func() { max.Div(SZero) })
+	assert.Equal(t, min, max.Neg().Sub(unit))
+	assert.Equal(t, min, min.Mul(MustParseSigned("1")))
+}
+
+func TestSignedShopspring(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	maxValue := decimal.RequireFromString("92233720368.54775807")
+	minValue := decimal.RequireFromString("-92233720368.54775808")
+
+	for i := 0; i < 20000; i++ {
+		a := randomUnits(rnd).Div(decimal.NewFromInt(2)).Truncate(8)
+		b := randomUnits(rnd).Div(decimal.NewFromInt(2)).Truncate(8)
+		if rnd.Intn(2) == 0 {
+			a = a.Neg()
+		}
+		if rnd.Intn(2) == 0 {
+			b = b.Neg()
+		}
+		f0 := MustParseSigned(a.String())
+		f1 := MustParseSigned(b.String())
+
+		check := func(op string, expected decimal.Decimal, fn func() SDecimal) {
+			if expected.GreaterThan(maxValue) || expected.LessThan(minValue) {
+				assert.Panics(t, func() { fn() }, "%s %s %s", a, op, b)
+				return
+			}
+			assert.Equal(t, expected.String(), fn().String(), "%s %s %s", a, op, b)
+		}
+
+		check("+", a.Add(b), func() SDecimal { return f0.Add(f1) })
+		check("-", a.Sub(b), func() SDecimal { return f0.Sub(f1) })
+		check("*", a.Mul(b).Truncate(8), func() SDecimal { return f0.Mul(f1) })
+		if !b.IsZero() {
+			check("/", a.DivRound(b, 8), func() SDecimal { return f0.Div(f1) })
+		}
+		assert.Equal(t, a.Cmp(b), f0.Cmp(f1))
+	}
+}
+
+func TestSignedRound(t *testing.T) {
+	f := MustParseSigned("-1.125")
+	assert.Equal(t, "-1.13", f.Round(2).String())
+	assert.Equal(t, "-1.12", f.RoundWith(2, RoundHalfEven).String())
+	assert.Equal(t, "-1.12", f.RoundWith(2, RoundHalfDown).String())
+	assert.Equal(t, "-1.12", f.RoundWith(2, RoundDown).String())
+	assert.Equal(t, "-1.13", f.RoundWith(2, RoundUp).String())
+	assert.Equal(t, "-1.12", f.RoundWith(2, RoundCeiling).String())
+	assert.Equal(t, "-1.13", f.RoundWith(2, RoundFloor).String())
+
+	f = MustParseSigned("1.125")
+	assert.Equal(t, "1.13", f.RoundWith(2, RoundCeiling).String())
+	assert.Equal(t, "1.12", f.RoundWith(2, RoundFloor).String())
+}
+
+func TestSignedCompare(t *testing.T) {
+	a := MustParseSigned("-1")
+	b := MustParseSigned("0.5")
+
+	assert.True(t, a.LessThan(b))
+	assert.True(t, a.LessThanOrEqual(b))
+	assert.True(t, a.LessThanOrEqual(a))
+	assert.True(t, b.GreaterThan(a))
+	assert.True(t, b.GreaterThanOrEqual(a))
+	assert.True(t, a.Equal(MustParseSigned("-1.0")))
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(a))
+}
+
+func TestSignedConversions(t *testing.T) {
+	s, err := MustParse("123.45").Signed()
+	assert.NoError(t, err)
+	assert.Equal(t, MustParseSigned("123.45"), s)
+	s, err = MustParse("92233720368.54775807").Signed()
+	assert.NoError(t, err)
+	assert.Equal(t, MustParseSigned("92233720368.54775807"), s)
+	_, err = MustParse("95000000000").Signed()
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = Max.Signed()
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	assert.Equal(t, MustParse("123.45"), MustParseSigned("-123.45").Abs())
+	assert.Equal(t, MustParse("92233720368.54775808"), MustParseSigned("-92233720368.54775808").Abs())
+
+	u, err := MustParseSigned("123.45").Unsigned()
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("123.45"), u)
+
+	_, err = MustParseSigned("-0.00000001").Unsigned()
+	assert.True(t, errors.Is(err, ErrNegative))
+
+	s, err = MustParse("1").SubSigned(MustParse("2.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, "-1.5", s.String())
+	s, err = MustParse("2.5").SubSigned(MustParse("1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", s.String())
+	s, err = Max.SubSigned(MustParse("10000000000"))
+	assert.NoError(t, err)
+	assert.Equal(t, "89999999999.99999999", s.String())
+	s, err = Zero.SubSigned(MustParse("92233720368.54775808"))
+	assert.NoError(t, err)
+	assert.Equal(t, "-92233720368.54775808", s.String())
+	_, err = Max.SubSigned(Zero)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = Zero.SubSigned(Max)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestSignedStringN(t *testing.T) {
+	assert.Equal(t, "-1.10", MustParseSigned("-1.1").StringN(2))
+	assert.Equal(t, "-1", MustParseSigned("-1.123").StringN(0))
+	assert.Equal(t, "1.12", MustParseSigned("1.123").StringN(2))
+}
+
+func TestSignedEncodeDecode(t *testing.T) {
+	for _, s := range []string{"0", "-0.00000001", "12345.12345", "-12345.12345", "-92233720368.54775808", "92233720368.54775807"} {
+		f := MustParseSigned(s)
+
+		b := &bytes.Buffer{}
+		assert.NoError(t, f.WriteTo(b))
+		f0, err := ReadSignedFrom(b)
+		assert.NoError(t, err)
+		assert.Equal(t, f, f0)
+
+		data, err := f.MarshalBinary()
+		assert.NoError(t, err)
+		var f1 SDecimal
+		assert.NoError(t, f1.UnmarshalBinary(data))
+		assert.Equal(t, f, f1)
+
+		rem, err := f1.UnmarshalBinaryData(append(data, 1, 2))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, 2}, rem)
+	}
+
+	data, _ := MustParseSigned("-0.00000001").MarshalBinary()
+	assert.Equal(t, []byte{1}, data)
+
+	var f SDecimal
+	assert.Error(t, f.UnmarshalBinary(nil))
+}
+
+type SJStruct struct {
+	F SDecimal `json:"f"`
+}
+
+func TestSignedJSON(t *testing.T) {
+	for _, s := range []string{"-1234567.1234567", "0", "1.5"} {
+		j := SJStruct{F: MustParseSigned(s)}
+
+		data, err := json.Marshal(&j)
+		assert.NoError(t, err)
+
+		var j0 SJStruct
+		assert.NoError(t, json.Unmarshal(data, &j0))
+		assert.Equal(t, j, j0)
+	}
+
+	data, err := json.Marshal(MustParseSigned("-1.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, "-1.50000000", string(data))
+
+	var f SDecimal
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &f))
+	assert.NoError(t, json.Unmarshal([]byte(`"-1.5"`), &f))
+	assert.Equal(t, MustParseSigned("-1.5"), f)
+
+	data, err = f.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "-1.5", string(data))
+	assert.NoError(t, f.UnmarshalText([]byte("-2")))
+	assert.Equal(t, MustParseSigned("-2"), f)
+}
+
+func TestSignedAppendString(t *testing.T) {
+	buf := []byte("pnl=")
+	assert.Equal(t, "pnl=-1.5", string(MustParseSigned("-1.5").AppendString(buf)))
+	assert.Equal(t, "pnl=-1.50", string(MustParseSigned("-1.5").AppendStringN(buf, 2)))
+	assert.Equal(t, "pnl=2", string(MustParseSigned("2").AppendString(buf)))
+
+	b, err := MustParseSigned("-0.25").AppendText(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "pnl=-0.25", string(b))
+}
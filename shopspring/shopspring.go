@@ -0,0 +1,65 @@
+// Package shopspring converts between udecimal and github.com/shopspring/decimal values.
+package shopspring
+
+import (
+	"fmt"
+
+	"github.com/geseq/udecimal"
+	"github.com/shopspring/decimal"
+)
+
+// maxExponent is the largest exponent of a non-zero shopspring decimal that can be less than udecimal.Max
+const maxExponent = 10
+
+// From converts a shopspring decimal to a udecimal.Decimal. Values with more than 8 decimal places are
+// rounded according to mode, otherwise the conversion is exact. It returns udecimal.ErrNegative for
+// negative values and udecimal.ErrOverflow if the result is larger than udecimal.Max.
+func From(d decimal.Decimal, mode udecimal.RoundMode) (udecimal.Decimal, error) {
+	if d.IsZero() {
+		return udecimal.Zero, nil
+	}
+	if d.Sign() < 0 {
+		return udecimal.Zero, udecimal.ErrNegative
+	}
+	if d.Exponent() > maxExponent {
+		return udecimal.Zero, udecimal.ErrOverflow
+	}
+	if exp := d.Exponent(); exp >= -8 {
+		if c := d.Coefficient(); c.IsInt64() {
+			return udecimal.FromMantissaExponent(c.Int64(), int8(exp))
+		}
+	}
+	return udecimal.FromRat(d.Rat(), mode)
+}
+
+// To converts f to a shopspring decimal. The conversion is exact.
+func To(f udecimal.Decimal) decimal.Decimal {
+	m, exp, err := f.MantissaExponent()
+	if err != nil {
+		return decimal.NewFromBigInt(f.BigInt(), -8)
+	}
+	return decimal.New(m, int32(exp))
+}
+
+// FromSlice converts a slice of shopspring decimals as From does. The error reports the index of the
+// first value that could not be converted.
+func FromSlice(ds []decimal.Decimal, mode udecimal.RoundMode) ([]udecimal.Decimal, error) {
+	fs := make([]udecimal.Decimal, len(ds))
+	for i, d := range ds {
+		f, err := From(d, mode)
+		if err != nil {
+			return nil, fmt.Errorf("shopspring: converting value %d: %w", i, err)
+		}
+		fs[i] = f
+	}
+	return fs, nil
+}
+
+// ToSlice converts a slice of Decimals to shopspring decimals as To does
+func ToSlice(fs []udecimal.Decimal) []decimal.Decimal {
+	ds := make([]decimal.Decimal, len(fs))
+	for i, f := range fs {
+		ds[i] = To(f)
+	}
+	return ds
+}
@@ -0,0 +1,175 @@
+package shopspring_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/geseq/udecimal"
+	. "github.com/geseq/udecimal/shopspring"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	maxValue = decimal.RequireFromString("99999999999.99999999")
+	modes    = []udecimal.RoundMode{udecimal.RoundHalfUp, udecimal.RoundHalfDown, udecimal.RoundHalfEven, udecimal.RoundDown, udecimal.RoundUp, udecimal.RoundCeiling, udecimal.RoundFloor}
+)
+
+// randomValue returns a random value with 8 decimal places and up to 19 significant digits
+func randomValue(rnd *rand.Rand) decimal.Decimal {
+	digits := rnd.Intn(19) + 1
+	units := new(big.Int).Rand(rnd, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+	return decimal.NewFromBigInt(units, -8)
+}
+
+// round rounds d to places decimal places with the shopspring equivalent of mode
+func round(d decimal.Decimal, places int32, mode udecimal.RoundMode) decimal.Decimal {
+	switch mode {
+	case udecimal.RoundHalfUp:
+		return d.Round(places)
+	case udecimal.RoundHalfDown:
+		truncated := d.Truncate(places)
+		if d.Sub(truncated).Equal(decimal.New(5, -places-1)) {
+			return truncated
+		}
+		return d.Round(places)
+	case udecimal.RoundHalfEven:
+		return d.RoundBank(places)
+	case udecimal.RoundDown:
+		return d.RoundDown(places)
+	case udecimal.RoundUp:
+		return d.RoundUp(places)
+	case udecimal.RoundCeiling:
+		return d.RoundCeil(places)
+	}
+	return d.RoundFloor(places)
+}
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		input    string
+		mode     udecimal.RoundMode
+		expected string
+	}{
+		{"0", udecimal.RoundDown, "0"},
+		{"1.5", udecimal.RoundDown, "1.5"},
+		{"1e10", udecimal.RoundDown, "10000000000"},
+		{"99999999999.99999999", udecimal.RoundDown, "99999999999.99999999"},
+		{"0.123456785", udecimal.RoundHalfEven, "0.12345678"},
+		{"0.123456785", udecimal.RoundHalfUp, "0.12345679"},
+		{"0.000000001", udecimal.RoundUp, "0.00000001"},
+		{"1e-1000", udecimal.RoundHalfUp, "0"},
+		{"12345678901234567890123e-20", udecimal.RoundDown, "123.45678901"},
+	}
+
+	for _, tt := range tests {
+		f, err := From(decimal.RequireFromString(tt.input), tt.mode)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, f.String(), tt.input)
+		}
+	}
+
+	_, err := From(decimal.RequireFromString("-0.00000001"), udecimal.RoundDown)
+	assert.True(t, errors.Is(err, udecimal.ErrNegative))
+	_, err = From(decimal.RequireFromString("100000000000"), udecimal.RoundDown)
+	assert.True(t, errors.Is(err, udecimal.ErrOverflow))
+	_, err = From(decimal.RequireFromString("1e1000"), udecimal.RoundDown)
+	assert.True(t, errors.Is(err, udecimal.ErrOverflow))
+	_, err = From(decimal.RequireFromString("99999999999.999999995"), udecimal.RoundHalfUp)
+	assert.True(t, errors.Is(err, udecimal.ErrOverflow))
+}
+
+func TestTo(t *testing.T) {
+	assert.Equal(t, "1.5", To(udecimal.MustParse("1.5")).String())
+	assert.Equal(t, "0", To(udecimal.Zero).String())
+	assert.True(t, maxValue.Equal(To(udecimal.Max)))
+	assert.Equal(t, int32(-8), To(udecimal.Max).Exponent())
+}
+
+func TestSlice(t *testing.T) {
+	ds := []decimal.Decimal{decimal.RequireFromString("1.5"), decimal.RequireFromString("0.123456789")}
+	fs, err := FromSlice(ds, udecimal.RoundDown)
+	assert.NoError(t, err)
+	assert.Equal(t, []udecimal.Decimal{udecimal.MustParse("1.5"), udecimal.MustParse("0.12345678")}, fs)
+
+	ds = ToSlice(fs)
+	assert.Equal(t, 2, len(ds))
+	assert.Equal(t, "0.12345678", ds[1].String())
+
+	_, err = FromSlice([]decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(-1)}, udecimal.RoundDown)
+	assert.True(t, errors.Is(err, udecimal.ErrNegative))
+	assert.EqualError(t, err, "shopspring: converting value 1: decimal negative result")
+
+	assert.Empty(t, ToSlice(nil))
+}
+
+// TestDifferential runs the Decimal operations on random values and checks that they give the same results
+// as shopspring
+func TestDifferential(t *testing.T) {
+	rnd := rand.New(rand.NewSource(31))
+
+	for i := 0; i < 20000; i++ {
+		a := randomValue(rnd)
+		b := randomValue(rnd)
+		if rnd.Intn(10) == 0 {
+			b = a
+		}
+		fa, err := From(a, udecimal.RoundDown)
+		assert.NoError(t, err)
+		fb, err := From(b, udecimal.RoundDown)
+		assert.NoError(t, err)
+		assert.True(t, a.Equal(To(fa)))
+
+		check := func(op string, expected decimal.Decimal, result udecimal.Decimal, err error) {
+			switch {
+			case expected.Sign() < 0:
+				assert.True(t, errors.Is(err, udecimal.ErrNegative), "%s %s %s", a, op, b)
+			case expected.GreaterThan(maxValue):
+				assert.True(t, errors.Is(err, udecimal.ErrOverflow), "%s %s %s", a, op, b)
+			default:
+				if assert.NoError(t, err, "%s %s %s", a, op, b) {
+					assert.Equal(t, expected.String(), result.String(), "%s %s %s", a, op, b)
+				}
+			}
+		}
+
+		f, err := fa.AddErr(fb)
+		check("+", a.Add(b), f, err)
+		f, err = fa.SubErr(fb)
+		check("-", a.Sub(b), f, err)
+		f, err = fa.MulErr(fb)
+		check("*", a.Mul(b).Truncate(8), f, err)
+		if !b.IsZero() {
+			f, err = fa.DivErr(fb)
+			check("/", a.DivRound(b, 8), f, err)
+		}
+
+		places := int32(rnd.Intn(9))
+		for _, mode := range modes {
+			f, err = fa.RoundWithErr(int(places), mode)
+			check(fmt.Sprintf("round %d %v", places, mode), round(a, places, mode), f, err)
+		}
+		check("truncate", a.Truncate(places), fa.Truncate(int(places)), nil)
+		check("floor", a.RoundFloor(places), fa.Floor(int(places)), nil)
+		if expected := a.RoundCeil(places); expected.LessThanOrEqual(maxValue) {
+			check("ceil", expected, fa.Ceil(int(places)), nil)
+		}
+
+		assert.Equal(t, a.Cmp(b), fa.Cmp(fb))
+		assert.Equal(t, a.Equal(b), fa.Equal(fb))
+		assert.Equal(t, a.LessThan(b), fa.LessThan(fb))
+		assert.Equal(t, a.LessThanOrEqual(b), fa.LessThanOrEqual(fb))
+		assert.Equal(t, a.GreaterThan(b), fa.GreaterThan(fb))
+		assert.Equal(t, a.GreaterThanOrEqual(b), fa.GreaterThanOrEqual(fb))
+
+		assert.Equal(t, a.String(), fa.String())
+		assert.Equal(t, a.Truncate(places).StringFixed(places), fa.StringN(int(places)))
+		assert.Equal(t, a.StringFixedBank(places), fmt.Sprintf("%.*f", places, fa))
+		assert.Equal(t, uint64(a.IntPart()), fa.Int())
+
+		assert.Equal(t, a.Rat().String(), fa.Rat().String())
+	}
+}
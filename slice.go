@@ -0,0 +1,231 @@
+package udecimal
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// ErrNotSorted is returned when encoding a slice with a delta encoding whose values are not in the required
+// order
+var ErrNotSorted = errors.New("decimal slice not sorted")
+
+// SliceEncoding selects how AppendSlice and WriteSlice encode consecutive values. The first value is always
+// encoded in full as a varint.
+type SliceEncoding byte
+
+const (
+	// SlicePlain encodes every value in full, as with MarshalBinary
+	SlicePlain SliceEncoding = iota
+	// SliceDeltaAsc encodes the difference from the previous value. The values must be sorted in ascending
+	// order.
+	SliceDeltaAsc
+	// SliceDeltaDesc encodes the difference to the previous value. The values must be sorted in descending
+	// order.
+	SliceDeltaDesc
+	// SliceDeltaOfDelta encodes the change in the signed difference between consecutive values, which is
+	// smallest for evenly spaced values such as price levels. The values may be in any order.
+	SliceDeltaOfDelta
+)
+
+func (e SliceEncoding) String() string {
+	switch e {
+	case SlicePlain:
+		return "Plain"
+	case SliceDeltaAsc:
+		return "DeltaAsc"
+	case SliceDeltaDesc:
+		return "DeltaDesc"
+	case SliceDeltaOfDelta:
+		return "DeltaOfDelta"
+	}
+	return "SliceEncoding(" + strconv.Itoa(int(e)) + ")"
+}
+
+// AppendSlice appends the values to dst with the specified encoding and returns the extended buffer. The
+// encoding is a header byte holding the encoding, the number of values as a uvarint and then a uvarint per
+// value. It returns ErrNotSorted if a delta encoding requires an order that the values are not in.
+func AppendSlice(dst []byte, values []Decimal, enc SliceEncoding) ([]byte, error) {
+	if enc > SliceDeltaOfDelta {
+		return dst, errFormat
+	}
+	dst = append(dst, byte(enc))
+	dst = appendUvarint(dst, uint64(len(values)))
+
+	var e sliceEncoder
+	for i, f := range values {
+		x, err := e.next(enc, i, f.fp)
+		if err != nil {
+			return dst, err
+		}
+		dst = appendUvarint(dst, x)
+	}
+	return dst, nil
+}
+
+// DecodeSlice decodes values encoded with AppendSlice from the start of data, appending them to dst. It
+// returns the extended slice and the remaining bytes.
+func DecodeSlice(data []byte, dst []Decimal) ([]Decimal, []byte, error) {
+	if len(data) == 0 || SliceEncoding(data[0]) > SliceDeltaOfDelta {
+		return dst, data, errFormat
+	}
+	enc := SliceEncoding(data[0])
+	count, n := binary.Uvarint(data[1:])
+	// every value takes at least one byte
+	if n <= 0 || count > uint64(len(data)-1-n) {
+		return dst, data, errFormat
+	}
+	rem := data[1+n:]
+
+	var d sliceDecoder
+	for i := 0; i < int(count); i++ {
+		x, n := binary.Uvarint(rem)
+		if n <= 0 {
+			return dst, data, errFormat
+		}
+		rem = rem[n:]
+
+		fp, err := d.next(enc, i, x)
+		if err != nil {
+			return dst, data, err
+		}
+		dst = append(dst, Decimal{fp: fp})
+	}
+	return dst, rem, nil
+}
+
+// WriteSlice writes the values to w in the format of AppendSlice
+func WriteSlice(w io.ByteWriter, values []Decimal, enc SliceEncoding) error {
+	if enc > SliceDeltaOfDelta {
+		return errFormat
+	}
+	if err := w.WriteByte(byte(enc)); err != nil {
+		return err
+	}
+	if err := writeUvarint(w, uint64(len(values))); err != nil {
+		return err
+	}
+
+	var e sliceEncoder
+	for i, f := range values {
+		x, err := e.next(enc, i, f.fp)
+		if err != nil {
+			return err
+		}
+		if err := writeUvarint(w, x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSlice reads values written with WriteSlice or AppendSlice from r, appending them to dst
+func ReadSlice(r io.ByteReader, dst []Decimal) ([]Decimal, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return dst, err
+	}
+	enc := SliceEncoding(b)
+	if enc > SliceDeltaOfDelta {
+		return dst, errFormat
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return dst, noEOF(err)
+	}
+
+	var d sliceDecoder
+	for i := uint64(0); i < count; i++ {
+		x, err := binary.ReadUvarint(r)
+		if err != nil {
+			return dst, noEOF(err)
+		}
+		fp, err := d.next(enc, int(i), x)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, Decimal{fp: fp})
+	}
+	return dst, nil
+}
+
+// noEOF reports an io.EOF part way through a slice as io.ErrUnexpectedEOF
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// sliceEncoder converts consecutive fixed point values to the uvarints to encode. Differences are computed
+// modulo 2^64 and encoded as zigzag signed values, which is exact for any pair of values.
+type sliceEncoder struct {
+	prev  uint64
+	delta uint64
+}
+
+func (e *sliceEncoder) next(enc SliceEncoding, i int, fp uint64) (uint64, error) {
+	prev := e.prev
+	e.prev = fp
+	if i == 0 || enc == SlicePlain {
+		return fp, nil
+	}
+
+	switch enc {
+	case SliceDeltaAsc:
+		if fp < prev {
+			return 0, ErrNotSorted
+		}
+		return fp - prev, nil
+	case SliceDeltaDesc:
+		if fp > prev {
+			return 0, ErrNotSorted
+		}
+		return prev - fp, nil
+	}
+
+	delta := fp - prev
+	dd := delta - e.delta
+	e.delta = delta
+	return zigzag(dd), nil
+}
+
+// sliceDecoder reverses sliceEncoder, rejecting deltas that break the order of a sorted encoding. Like
+// ReadFrom, it accepts any uint64, so values above MAX that Add can return decode as they were encoded.
+type sliceDecoder struct {
+	prev  uint64
+	delta uint64
+}
+
+func (d *sliceDecoder) next(enc SliceEncoding, i int, x uint64) (uint64, error) {
+	fp := x
+	if i > 0 {
+		switch enc {
+		case SliceDeltaAsc:
+			fp = d.prev + x
+			if fp < d.prev {
+				return 0, errFormat
+			}
+		case SliceDeltaDesc:
+			fp = d.prev - x
+			if x > d.prev {
+				return 0, errFormat
+			}
+		case SliceDeltaOfDelta:
+			d.delta += unzigzag(x)
+			fp = d.prev + d.delta
+		}
+	}
+	d.prev = fp
+	return fp, nil
+}
+
+// zigzag maps a two's complement difference to an unsigned value with small magnitudes near zero
+func zigzag(x uint64) uint64 {
+	return x<<1 ^ uint64(int64(x)>>63)
+}
+
+func unzigzag(x uint64) uint64 {
+	return x>>1 ^ -(x & 1)
+}
@@ -0,0 +1,179 @@
+package udecimal_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var sliceEncodings = []SliceEncoding{SlicePlain, SliceDeltaAsc, SliceDeltaDesc, SliceDeltaOfDelta}
+
+func TestSlice(t *testing.T) {
+	values := []Decimal{MustParse("100.01"), MustParse("100.02"), MustParse("100.03"), MustParse("100.05")}
+
+	data, err := AppendSlice([]byte("x"), values, SliceDeltaAsc)
+	assert.NoError(t, err)
+	// header, count, 100.01 in full then deltas of 0.01, 0.01 and 0.02
+	assert.Equal(t, 1+1+1+5+3+3+3, len(data))
+
+	decoded, rem, err := DecodeSlice(data[1:], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, values, decoded)
+	assert.Empty(t, rem)
+
+	data, err = AppendSlice(nil, values, SliceDeltaOfDelta)
+	assert.NoError(t, err)
+	// 100.01 in full, a delta of 0.01 then changes of 0 and 0.01
+	assert.Equal(t, 1+1+5+3+1+3, len(data))
+
+	_, err = AppendSlice(nil, values, SliceDeltaDesc)
+	assert.True(t, errors.Is(err, ErrNotSorted))
+	_, err = AppendSlice(nil, []Decimal{Max, Zero}, SliceDeltaAsc)
+	assert.True(t, errors.Is(err, ErrNotSorted))
+	assert.True(t, errors.Is(WriteSlice(&bytes.Buffer{}, values, SliceDeltaDesc), ErrNotSorted))
+	_, err = AppendSlice(nil, values, SliceEncoding(9))
+	assert.Error(t, err)
+
+	data, err = AppendSlice(nil, nil, SliceDeltaOfDelta)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{byte(SliceDeltaOfDelta), 0}, data)
+	decoded, _, err = DecodeSlice(data, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, decoded)
+
+	assert.Equal(t, "DeltaOfDelta", SliceDeltaOfDelta.String())
+	assert.Equal(t, "SliceEncoding(9)", SliceEncoding(9).String())
+}
+
+func TestSliceInvalid(t *testing.T) {
+	max, _ := Max.Add(FromUnits(8446744073709551616)).MarshalBinary()
+
+	for _, data := range [][]byte{
+		nil,
+		{9, 0},
+		{byte(SlicePlain)},
+		{byte(SlicePlain), 2, 1},
+		{byte(SliceDeltaDesc), 2, 1, 2},
+		append(append([]byte{byte(SliceDeltaAsc), 2}, max...), 1),
+		{byte(SlicePlain), 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02},
+	} {
+		_, _, err := DecodeSlice(data, nil)
+		assert.Error(t, err, "%v", data)
+
+		_, err = ReadSlice(bytes.NewReader(data), nil)
+		assert.Error(t, err, "%v", data)
+	}
+}
+
+func TestSliceAboveMax(t *testing.T) {
+	// Add can return values above MAX, and a slice written with them reads back
+	values := []Decimal{MustParse("90000000000").Add(MustParse("90000000000")), Max, Zero, Max.Add(FromUnits(8446744073709551616))}
+	for _, enc := range []SliceEncoding{SlicePlain, SliceDeltaOfDelta} {
+		data, err := AppendSlice(nil, values, enc)
+		assert.NoError(t, err)
+		decoded, _, err := DecodeSlice(data, nil)
+		assert.NoError(t, err, "%v", enc)
+		assert.Equal(t, values, decoded, "%v", enc)
+
+		var buf bytes.Buffer
+		assert.NoError(t, WriteSlice(&buf, values, enc))
+		decoded, err = ReadSlice(&buf, nil)
+		assert.NoError(t, err, "%v", enc)
+		assert.Equal(t, values, decoded, "%v", enc)
+	}
+
+	data, err := AppendSlice(nil, []Decimal{Max, values[0]}, SliceDeltaAsc)
+	assert.NoError(t, err)
+	decoded, _, err := DecodeSlice(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []Decimal{Max, values[0]}, decoded)
+}
+
+// TestSliceRoundTrip encodes random slices with every encoding, checking that they decode to the same
+// values and that every truncation of the encoding is rejected
+func TestSliceRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(17))
+	random := func() []Decimal {
+		values := make([]Decimal, rnd.Intn(50))
+		switch rnd.Intn(3) {
+		case 0:
+			for i := range values {
+				values[i] = MustParse(randomUnits(rnd).String())
+			}
+		case 1:
+			price := MustParse(randomUnits(rnd).Truncate(2).String())
+			tick := MustParse("0.01")
+			for i := range values {
+				values[i] = price
+				if price.LessThan(Max.Sub(tick)) {
+					price = price.Add(tick)
+				}
+			}
+		default:
+			extremes := []Decimal{Zero, SmallestUnit, Max, Max.Sub(SmallestUnit)}
+			for i := range values {
+				values[i] = extremes[rnd.Intn(len(extremes))]
+			}
+		}
+		return values
+	}
+
+	for i := 0; i < 500; i++ {
+		values := random()
+		for _, enc := range sliceEncodings {
+			v := append([]Decimal{}, values...)
+			switch enc {
+			case SliceDeltaAsc:
+				sort.Slice(v, func(i, j int) bool { return v[i].LessThan(v[j]) })
+			case SliceDeltaDesc:
+				sort.Slice(v, func(i, j int) bool { return v[i].GreaterThan(v[j]) })
+			}
+
+			data, err := AppendSlice(nil, v, enc)
+			if !assert.NoError(t, err, "%v %v", enc, v) {
+				continue
+			}
+			decoded, rem, err := DecodeSlice(append(data, 7), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, v, append([]Decimal{}, decoded...), "%v", enc)
+			assert.Equal(t, []byte{7}, rem)
+
+			var buf bytes.Buffer
+			assert.NoError(t, WriteSlice(&buf, v, enc))
+			assert.Equal(t, data, buf.Bytes())
+			decoded, err = ReadSlice(&buf, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, v, append([]Decimal{}, decoded...), "%v", enc)
+
+			for n := 0; n < len(data); n++ {
+				_, _, err := DecodeSlice(data[:n], nil)
+				assert.Error(t, err, "%v %d", enc, n)
+
+				_, err = ReadSlice(bytes.NewReader(data[:n]), nil)
+				if n == 0 {
+					assert.Equal(t, io.EOF, err)
+				} else {
+					assert.Equal(t, io.ErrUnexpectedEOF, err, "%v %d", enc, n)
+				}
+			}
+		}
+	}
+}
+
+func TestSliceAllocs(t *testing.T) {
+	values := []Decimal{MustParse("100.01"), MustParse("100.02"), MustParse("100.03"), MustParse("100.05")}
+	data := make([]byte, 0, 64)
+	decoded := make([]Decimal, 0, len(values))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		data, _ = AppendSlice(data[:0], values, SliceDeltaOfDelta)
+		decoded, _, _ = DecodeSlice(data, decoded[:0])
+	})
+	assert.Equal(t, float64(0), allocs)
+}
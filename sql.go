@@ -0,0 +1,104 @@
+package udecimal
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Scan implements the sql.Scanner interface. Strings and byte slices are parsed with ParseStrict, so
+// values with more than 8 significant decimal places are rejected rather than truncated. Integers and
+// floats must be non-negative and, for floats, exactly representable with 8 decimal places.
+func (f *Decimal) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case string:
+		*f, err = ParseStrict(v)
+	case []byte:
+		*f, err = ParseStrict(string(v))
+	case int64:
+		*f, err = scanInt64(v)
+	case float64:
+		*f, err = scanFloat64(v)
+	case nil:
+		return errors.New("decimal: cannot scan NULL into Decimal, use NullDecimal")
+	default:
+		return fmt.Errorf("decimal: cannot scan type %T into Decimal", src)
+	}
+	return err
+}
+
+func scanInt64(v int64) (Decimal, error) {
+	if v < 0 {
+		return Zero, ErrNegative
+	}
+	if uint64(v) > maxFP/scale {
+		return Zero, ErrOverflow
+	}
+	return Decimal{fp: uint64(v) * scale}, nil
+}
+
+func scanFloat64(v float64) (Decimal, error) {
+	if v < 0 {
+		return Zero, ErrNegative
+	}
+	return ParseStrict(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// Value implements the driver.Valuer interface, returning the String form of f
+func (f Decimal) Value() (driver.Value, error) {
+	return f.String(), nil
+}
+
+// NullDecimal is a Decimal that may be null. It implements sql.Scanner and driver.Valuer, and is
+// marshalled to and from JSON null when not Valid.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool // Valid is true if Decimal is not NULL
+}
+
+// Scan implements the sql.Scanner interface
+func (n *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		n.Decimal, n.Valid = Zero, false
+		return nil
+	}
+	if err := n.Decimal.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal.Value()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Unlike Decimal, null sets the NullDecimal to
+// not Valid.
+func (n *NullDecimal) UnmarshalJSON(bytes []byte) error {
+	if string(bytes) == "null" {
+		n.Decimal, n.Valid = Zero, false
+		return nil
+	}
+	if err := n.Decimal.UnmarshalJSON(bytes); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NullDecimal) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Decimal.MarshalJSON()
+}
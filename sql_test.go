@@ -0,0 +1,129 @@
+package udecimal_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ sql.Scanner   = (*Decimal)(nil)
+	_ driver.Valuer = Decimal{}
+	_ sql.Scanner   = (*NullDecimal)(nil)
+	_ driver.Valuer = NullDecimal{}
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src      interface{}
+		expected string
+	}{
+		{"123.45678901", "123.45678901"},
+		{"1.50000000", "1.5"},
+		{[]byte("99999999999.99999999"), "99999999999.99999999"},
+		{int64(0), "0"},
+		{int64(99999999999), "99999999999"},
+		{float64(1.5), "1.5"},
+		{float64(0.1), "0.1"},
+		{float64(123.45678901), "123.45678901"},
+	}
+
+	for _, tt := range tests {
+		var f Decimal
+		if assert.NoError(t, f.Scan(tt.src), "%v", tt.src) {
+			assert.Equal(t, tt.expected, f.String())
+		}
+	}
+
+	errs := []struct {
+		src interface{}
+		err error
+	}{
+		{"1.123456789", ErrPrecision},
+		{"-1", ErrSyntax},
+		{"abc", ErrSyntax},
+		{[]byte("1e12"), ErrOverflow},
+		{int64(-1), ErrNegative},
+		{int64(100000000000), ErrOverflow},
+		{float64(-0.5), ErrNegative},
+		{float64(0.123456789), ErrPrecision},
+		{float64(1e11), ErrOverflow},
+		{math.NaN(), ErrSyntax},
+		{math.Inf(1), ErrSyntax},
+	}
+
+	for _, tt := range errs {
+		var f Decimal
+		err := f.Scan(tt.src)
+		assert.True(t, errors.Is(err, tt.err), "%v: %v", tt.src, err)
+	}
+
+	var f Decimal
+	assert.Error(t, f.Scan(nil))
+	assert.Error(t, f.Scan(true))
+}
+
+func TestValue(t *testing.T) {
+	v, err := MustParse("1.50").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", v)
+
+	var f Decimal
+	assert.NoError(t, f.Scan(v))
+	assert.Equal(t, MustParse("1.5"), f)
+}
+
+func TestNullDecimal(t *testing.T) {
+	var n NullDecimal
+	assert.NoError(t, n.Scan("2.5"))
+	assert.True(t, n.Valid)
+	assert.Equal(t, MustParse("2.5"), n.Decimal)
+
+	v, err := n.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "2.5", v)
+
+	assert.NoError(t, n.Scan(nil))
+	assert.False(t, n.Valid)
+	assert.Equal(t, Zero, n.Decimal)
+
+	v, err = n.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	assert.Error(t, n.Scan("abc"))
+	assert.False(t, n.Valid)
+}
+
+type NStruct struct {
+	N NullDecimal `json:"n"`
+}
+
+func TestNullDecimalJSON(t *testing.T) {
+	data, err := json.Marshal(NStruct{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"n":null}`, string(data))
+
+	data, err = json.Marshal(NStruct{N: NullDecimal{Decimal: MustParse("1.5"), Valid: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"n":1.50000000}`, string(data))
+
+	var s NStruct
+	assert.NoError(t, json.Unmarshal(data, &s))
+	assert.Equal(t, NullDecimal{Decimal: MustParse("1.5"), Valid: true}, s.N)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"n":null}`), &s))
+	assert.Equal(t, NullDecimal{}, s.N)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"n":"0.25"}`), &s))
+	assert.Equal(t, NullDecimal{Decimal: MustParse("0.25"), Valid: true}, s.N)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"n":"x"}`), &s))
+	assert.False(t, s.N.Valid)
+}
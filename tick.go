@@ -0,0 +1,418 @@
+package udecimal
+
+import "encoding/binary"
+
+// DefaultTickBlockSize is the number of values per block used by NewTickEncoder when the block size is not
+// positive
+const DefaultTickBlockSize = 1024
+
+// TickEncoder compresses a stream of Decimals, such as trade prices, in the style of the Gorilla time
+// series encoding. Values are grouped into blocks which can be decoded independently. The first value of a
+// block is stored in full and every following value as the change in its difference from the previous
+// value, the delta-of-delta. As fixed point values are integers that are usually multiples of a tick size,
+// a delta-of-delta is stored as its decimal exponent, only when that changes, and a bit packed mantissa.
+// A repeated difference takes 1 bit and a typical tick sized change 6 bits.
+//
+// Each block is a uvarint count of values, a uvarint length in bytes and the bit packed values.
+type TickEncoder struct {
+	blockSize int
+	data      []byte // the completed blocks
+	bits      bitWriter
+	count     int // the number of values in the current block
+	blocks    int // the number of completed blocks
+	values    int // the number of values in the completed blocks
+	state     tickState
+}
+
+// tickState is the delta-of-delta state within a block
+type tickState struct {
+	prev  uint64
+	delta uint64
+	exp   uint64
+}
+
+// NewTickEncoder creates a TickEncoder with blockSize values per block
+func NewTickEncoder(blockSize int) *TickEncoder {
+	if blockSize <= 0 {
+		blockSize = DefaultTickBlockSize
+	}
+	return &TickEncoder{blockSize: blockSize}
+}
+
+// ResumeTickEncoder creates a TickEncoder that appends to data previously returned by TickEncoder.Bytes.
+// Values are added to the last block until it holds blockSize values.
+func ResumeTickEncoder(data []byte, blockSize int) (*TickEncoder, error) {
+	e := NewTickEncoder(blockSize)
+
+	d := NewTickDecoder(data)
+	if err := d.index(); err != nil {
+		return nil, err
+	}
+	if len(d.offsets) == 0 {
+		return e, nil
+	}
+
+	last := len(d.offsets) - 1
+	if err := d.Seek(last); err != nil {
+		return nil, err
+	}
+	for d.Next() {
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	e.data = append(e.data, data[:d.offsets[last]]...)
+	e.blocks = last
+	e.values = d.counts[last] - d.block.count
+	e.count = d.block.count
+	e.state = d.block.state
+	e.bits.b = append(e.bits.b, d.block.bits.b[:(d.block.bits.n+7)/8]...)
+	e.bits.n = d.block.bits.n
+	if e.count >= e.blockSize {
+		e.flush()
+	}
+	return e, nil
+}
+
+// Encode adds f to the stream. Any value can be encoded, including the values above MAX that Add can return.
+func (e *TickEncoder) Encode(f Decimal) {
+	if e.count == 0 {
+		e.bits.write(f.fp, 64)
+		e.state = tickState{prev: f.fp}
+	} else {
+		delta := f.fp - e.state.prev
+		e.writeDelta(delta - e.state.delta)
+		e.state.prev = f.fp
+		e.state.delta = delta
+	}
+
+	e.count++
+	if e.count >= e.blockSize {
+		e.flush()
+	}
+}
+
+// writeDelta writes a delta-of-delta, computed modulo 2^64
+func (e *TickEncoder) writeDelta(dd uint64) {
+	if dd == 0 {
+		e.bits.write(0, 1)
+		return
+	}
+	e.bits.write(1, 1)
+
+	var sign uint64
+	m := dd
+	if int64(dd) < 0 {
+		sign = 1
+		m = -dd
+	}
+	var exp uint64
+	for m%10 == 0 {
+		m /= 10
+		exp++
+	}
+	if exp == e.state.exp {
+		e.bits.write(0, 1)
+	} else {
+		e.bits.write(1, 1)
+		e.bits.write(exp, 5)
+		e.state.exp = exp
+	}
+
+	// m is at least 1 and at most 2^63
+	z := (m-1)<<1 | sign
+	switch {
+	case z < 1<<3:
+		e.bits.write(0, 1)
+		e.bits.write(z, 3)
+	case z < 1<<8:
+		e.bits.write(2, 2)
+		e.bits.write(z, 8)
+	case z < 1<<16:
+		e.bits.write(6, 3)
+		e.bits.write(z, 16)
+	default:
+		e.bits.write(7, 3)
+		e.bits.write(z, 64)
+	}
+}
+
+// flush completes the current block
+func (e *TickEncoder) flush() {
+	e.data = e.appendBlock(e.data)
+	e.blocks++
+	e.values += e.count
+	e.count = 0
+	e.bits.b = e.bits.b[:0]
+	e.bits.n = 0
+}
+
+func (e *TickEncoder) appendBlock(dst []byte) []byte {
+	dst = appendUvarint(dst, uint64(e.count))
+	dst = appendUvarint(dst, uint64(len(e.bits.b)))
+	return append(dst, e.bits.b...)
+}
+
+// Len returns the number of values encoded
+func (e *TickEncoder) Len() int {
+	return e.values + e.count
+}
+
+// Blocks returns the number of blocks, including a partially filled last block
+func (e *TickEncoder) Blocks() int {
+	if e.count > 0 {
+		return e.blocks + 1
+	}
+	return e.blocks
+}
+
+// Bytes returns the encoded stream. The returned slice is only valid until the next call to Encode.
+func (e *TickEncoder) Bytes() []byte {
+	if e.count == 0 {
+		return e.data
+	}
+	b := e.appendBlock(e.data)
+	e.data = b[:len(e.data)]
+	return b
+}
+
+// TickDecoder decodes a stream written by a TickEncoder. Successive calls to Next step through the values.
+type TickDecoder struct {
+	data    []byte
+	offsets []int // the start of each block, built on demand
+	counts  []int // the number of values before the end of each block
+	next    int   // the offset of the next block to decode
+	block   tickBlock
+	value   Decimal
+	err     error
+}
+
+// tickBlock is the decoding state of a block
+type tickBlock struct {
+	bits      bitReader
+	count     int // the number of values decoded
+	remaining int
+	state     tickState
+}
+
+// NewTickDecoder creates a TickDecoder for data returned by TickEncoder.Bytes
+func NewTickDecoder(data []byte) *TickDecoder {
+	return &TickDecoder{data: data}
+}
+
+// Next decodes the next value, returning false at the end of the stream or on error
+func (d *TickDecoder) Next() bool {
+	if d.err != nil {
+		return false
+	}
+	for d.block.remaining == 0 {
+		if d.next == len(d.data) {
+			return false
+		}
+		if !d.startBlock() {
+			return false
+		}
+	}
+
+	fp, ok := d.decode()
+	if !ok {
+		d.err = errFormat
+		return false
+	}
+	d.value = Decimal{fp: fp}
+	d.block.count++
+	d.block.remaining--
+	return true
+}
+
+// Value returns the value decoded by the last call to Next
+func (d *TickDecoder) Value() Decimal {
+	return d.value
+}
+
+// Err returns the first error encountered by Next
+func (d *TickDecoder) Err() error {
+	return d.err
+}
+
+// Blocks returns the number of blocks in the stream
+func (d *TickDecoder) Blocks() (int, error) {
+	if err := d.index(); err != nil {
+		return 0, err
+	}
+	return len(d.offsets), nil
+}
+
+// Seek positions the decoder at the start of the given block, so that the next call to Next returns its
+// first value
+func (d *TickDecoder) Seek(block int) error {
+	if err := d.index(); err != nil {
+		return err
+	}
+	if block < 0 || block > len(d.offsets) {
+		return errFormat
+	}
+	d.err = nil
+	d.block = tickBlock{}
+	if block == len(d.offsets) {
+		d.next = len(d.data)
+	} else {
+		d.next = d.offsets[block]
+	}
+	return nil
+}
+
+// index builds the block offsets by scanning the block headers
+func (d *TickDecoder) index() error {
+	if d.offsets != nil || len(d.data) == 0 {
+		return nil
+	}
+	var offsets, counts []int
+	values := 0
+	for i := 0; i < len(d.data); {
+		count, length, n := tickHeader(d.data[i:])
+		if n <= 0 {
+			return errFormat
+		}
+		offsets = append(offsets, i)
+		values += count
+		counts = append(counts, values)
+		i += n + length
+	}
+	d.offsets, d.counts = offsets, counts
+	return nil
+}
+
+// tickHeader decodes a block header, returning the length of the header and n <= 0 if it is invalid
+func tickHeader(b []byte) (count int, length int, n int) {
+	c, n0 := binary.Uvarint(b)
+	if n0 <= 0 || c == 0 {
+		return 0, 0, -1
+	}
+	l, n1 := binary.Uvarint(b[n0:])
+	if n1 <= 0 || l > uint64(len(b)-n0-n1) || c > l*8 {
+		return 0, 0, -1
+	}
+	return int(c), int(l), n0 + n1
+}
+
+func (d *TickDecoder) startBlock() bool {
+	b := d.data[d.next:]
+	count, length, n := tickHeader(b)
+	if n <= 0 {
+		d.err = errFormat
+		return false
+	}
+	d.block = tickBlock{bits: bitReader{b: b[n : n+length]}, remaining: count}
+	d.next += n + length
+	return true
+}
+
+func (d *TickDecoder) decode() (uint64, bool) {
+	r := &d.block.bits
+	s := &d.block.state
+	if d.block.count == 0 {
+		fp, ok := r.read(64)
+		*s = tickState{prev: fp}
+		return fp, ok
+	}
+
+	dd, ok := d.readDelta()
+	if !ok {
+		return 0, false
+	}
+	s.delta += dd
+	s.prev += s.delta
+	return s.prev, true
+}
+
+func (d *TickDecoder) readDelta() (uint64, bool) {
+	r := &d.block.bits
+	s := &d.block.state
+	if bit, ok := r.read(1); !ok || bit == 0 {
+		return 0, ok
+	}
+
+	if bit, ok := r.read(1); !ok {
+		return 0, false
+	} else if bit == 1 {
+		exp, ok := r.read(5)
+		if !ok || exp >= uint64(len(pow10)) {
+			return 0, false
+		}
+		s.exp = exp
+	}
+
+	width := uint(64)
+	for _, w := range []uint{3, 8, 16} {
+		bit, ok := r.read(1)
+		if !ok {
+			return 0, false
+		}
+		if bit == 0 {
+			width = w
+			break
+		}
+	}
+	z, ok := r.read(width)
+	if !ok {
+		return 0, false
+	}
+
+	dd := (z>>1 + 1) * pow10[s.exp]
+	if z&1 == 1 {
+		dd = -dd
+	}
+	return dd, true
+}
+
+// bitWriter appends bits most significant first
+type bitWriter struct {
+	b []byte
+	n uint // the number of bits written
+}
+
+// write writes the low width bits of v
+func (w *bitWriter) write(v uint64, width uint) {
+	for width > 0 {
+		if w.n%8 == 0 {
+			w.b = append(w.b, 0)
+		}
+		free := 8 - w.n%8
+		k := free
+		if width < k {
+			k = width
+		}
+		bits := byte(v>>(width-k)) & (1<<k - 1)
+		w.b[len(w.b)-1] |= bits << (free - k)
+		w.n += k
+		width -= k
+	}
+}
+
+// bitReader reads bits written by a bitWriter
+type bitReader struct {
+	b []byte
+	n uint // the number of bits read
+}
+
+// read reads width bits, returning false if there are not enough
+func (r *bitReader) read(width uint) (uint64, bool) {
+	if r.n+width > uint(len(r.b))*8 {
+		return 0, false
+	}
+	var v uint64
+	for width > 0 {
+		avail := 8 - r.n%8
+		k := avail
+		if width < k {
+			k = width
+		}
+		bits := r.b[r.n/8] >> (avail - k) & (1<<k - 1)
+		v = v<<k | uint64(bits)
+		r.n += k
+		width -= k
+	}
+	return v, true
+}
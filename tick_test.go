@@ -0,0 +1,206 @@
+package udecimal_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomWalk returns n prices starting at start that move by up to 2 ticks at a time
+func randomWalk(rnd *rand.Rand, n int, start, tick Decimal) []Decimal {
+	values := make([]Decimal, n)
+	price := start
+	for i := range values {
+		values[i] = price
+		switch rnd.Intn(5) {
+		case 0:
+			price = price.Add(tick)
+		case 1:
+			if price.GreaterThan(tick) {
+				price = price.Sub(tick)
+			}
+		case 2:
+			price = price.Add(tick).Add(tick)
+		}
+	}
+	return values
+}
+
+func decodeTicks(t *testing.T, data []byte) []Decimal {
+	var values []Decimal
+	d := NewTickDecoder(data)
+	for d.Next() {
+		values = append(values, d.Value())
+	}
+	assert.NoError(t, d.Err())
+	return values
+}
+
+func TestTickEncoder(t *testing.T) {
+	values := []Decimal{MustParse("100.01"), MustParse("100.02"), MustParse("100.03"), MustParse("100.03"), MustParse("99.5"), Zero, Max, SmallestUnit}
+
+	e := NewTickEncoder(3)
+	for _, f := range values {
+		e.Encode(f)
+	}
+	assert.Equal(t, len(values), e.Len())
+	assert.Equal(t, 3, e.Blocks())
+	assert.Equal(t, values, decodeTicks(t, e.Bytes()))
+
+	d := NewTickDecoder(e.Bytes())
+	blocks, err := d.Blocks()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, blocks)
+
+	assert.NoError(t, d.Seek(2))
+	assert.True(t, d.Next())
+	assert.Equal(t, Max, d.Value())
+
+	assert.NoError(t, d.Seek(1))
+	var rest []Decimal
+	for d.Next() {
+		rest = append(rest, d.Value())
+	}
+	assert.Equal(t, values[3:], rest)
+
+	assert.NoError(t, d.Seek(3))
+	assert.False(t, d.Next())
+	assert.Error(t, d.Seek(4))
+	assert.Error(t, d.Seek(-1))
+
+	e = NewTickEncoder(0)
+	assert.Empty(t, e.Bytes())
+	assert.Empty(t, decodeTicks(t, nil))
+	assert.Equal(t, 0, e.Blocks())
+}
+
+func TestTickEncoderSize(t *testing.T) {
+	e := NewTickEncoder(0)
+	price := MustParse("100")
+	tick := MustParse("0.01")
+	for i := 0; i < 1000; i++ {
+		e.Encode(price)
+		price = price.Add(tick)
+	}
+	// 64 bits, a first delta with its exponent in 11 bits and then a repeated difference of 1 bit per value
+	assert.Equal(t, 2+2+(64+11+998+7)/8, len(e.Bytes()))
+}
+
+func TestTickRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(23))
+	random := func() []Decimal {
+		n := rnd.Intn(300)
+		switch rnd.Intn(3) {
+		case 0:
+			return randomWalk(rnd, n, MustParse(randomUnits(rnd).Truncate(2).String()), MustParse("0.01"))
+		case 1:
+			values := make([]Decimal, n)
+			for i := range values {
+				values[i] = MustParse(randomUnits(rnd).String())
+			}
+			return values
+		}
+		extremes := []Decimal{Zero, SmallestUnit, Max, Max.Sub(SmallestUnit), MustParse("50000000000")}
+		values := make([]Decimal, n)
+		for i := range values {
+			values[i] = extremes[rnd.Intn(len(extremes))]
+		}
+		return values
+	}
+
+	for i := 0; i < 300; i++ {
+		values := random()
+		blockSize := rnd.Intn(100) + 1
+
+		e := NewTickEncoder(blockSize)
+		for _, f := range values {
+			e.Encode(f)
+		}
+		data := append([]byte{}, e.Bytes()...)
+		decoded := decodeTicks(t, data)
+		if len(values) == 0 {
+			assert.Empty(t, decoded)
+			continue
+		}
+		assert.Equal(t, values, decoded)
+
+		// resuming part way through gives the same encoding
+		split := rnd.Intn(len(values) + 1)
+		e = NewTickEncoder(blockSize)
+		for _, f := range values[:split] {
+			e.Encode(f)
+		}
+		e, err := ResumeTickEncoder(append([]byte{}, e.Bytes()...), blockSize)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, split, e.Len())
+		for _, f := range values[split:] {
+			e.Encode(f)
+		}
+		assert.Equal(t, data, e.Bytes())
+
+		// each block decodes on its own
+		d := NewTickDecoder(data)
+		block := rnd.Intn((len(values) + blockSize - 1) / blockSize)
+		assert.NoError(t, d.Seek(block))
+		var rest []Decimal
+		for d.Next() {
+			rest = append(rest, d.Value())
+		}
+		assert.Equal(t, values[block*blockSize:], rest)
+
+		// truncated data is rejected unless it ends on a block boundary
+		n := rnd.Intn(len(data))
+		d = NewTickDecoder(data[:n])
+		count := 0
+		for d.Next() {
+			assert.Equal(t, values[count], d.Value())
+			count++
+		}
+		_, err = ResumeTickEncoder(data[:n], blockSize)
+		if d.Err() == nil {
+			assert.NoError(t, err)
+			assert.Equal(t, 0, count%blockSize, "%d of %d", n, len(data))
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestTickAboveMax(t *testing.T) {
+	// Add can return values above MAX, and the rest of the block still decodes after them
+	large := MustParse("90000000000").Add(MustParse("90000000000"))
+	values := []Decimal{MustParse("100.01"), large, Max, Max.Add(FromUnits(8446744073709551616)), Zero, MustParse("100.02")}
+
+	e := NewTickEncoder(10)
+	for _, f := range values {
+		e.Encode(f)
+	}
+	assert.Equal(t, values, decodeTicks(t, e.Bytes()))
+
+	e, err := ResumeTickEncoder(e.Bytes(), 10)
+	if assert.NoError(t, err) {
+		e.Encode(large)
+		assert.Equal(t, append(values, large), decodeTicks(t, e.Bytes()))
+	}
+}
+
+func TestTickInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		{0},
+		{1},
+		{1, 9, 0},
+		{2, 8, 0, 0, 0, 0, 0, 0, 0, 0},
+	} {
+		d := NewTickDecoder(data)
+		for d.Next() {
+		}
+		assert.Error(t, d.Err(), "%v", data)
+
+		_, err := ResumeTickEncoder(data, 10)
+		assert.Error(t, err, "%v", data)
+	}
+}
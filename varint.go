@@ -18,3 +18,21 @@ func writeUvarint(w io.ByteWriter, x uint64) error {
 	}
 	return w.WriteByte(byte(x))
 }
+
+// writeVarint encodes an int64 onto w using zigzag encoding
+func writeVarint(w io.ByteWriter, x int64) error {
+	ux := uint64(x) << 1
+	if x < 0 {
+		ux = ^ux
+	}
+	return writeUvarint(w, ux)
+}
+
+// appendUvarint appends the varint encoding of x to dst
+func appendUvarint(dst []byte, x uint64) []byte {
+	for x >= 0x80 {
+		dst = append(dst, byte(x)|0x80)
+		x >>= 7
+	}
+	return append(dst, byte(x))
+}
@@ -0,0 +1,239 @@
+package udecimal
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"sync"
+)
+
+// PutUint64BE encodes the fixed point value of f into b as a big-endian uint64. It panics if b is shorter
+// than 8 bytes.
+func (f Decimal) PutUint64BE(b []byte) {
+	binary.BigEndian.PutUint64(b, f.fp)
+}
+
+// PutUint64LE encodes the fixed point value of f into b as a little-endian uint64. It panics if b is
+// shorter than 8 bytes.
+func (f Decimal) PutUint64LE(b []byte) {
+	binary.LittleEndian.PutUint64(b, f.fp)
+}
+
+// DecodeUint64BE decodes a Decimal encoded with PutUint64BE from the start of b. As with UnmarshalBinary,
+// any uint64 is accepted, so every value PutUint64BE writes decodes, including those above MAX.
+func DecodeUint64BE(b []byte) (Decimal, error) {
+	if len(b) < 8 {
+		return Zero, errFormat
+	}
+	return Decimal{fp: binary.BigEndian.Uint64(b)}, nil
+}
+
+// DecodeUint64LE decodes a Decimal encoded with PutUint64LE from the start of b
+func DecodeUint64LE(b []byte) (Decimal, error) {
+	if len(b) < 8 {
+		return Zero, errFormat
+	}
+	return Decimal{fp: binary.LittleEndian.Uint64(b)}, nil
+}
+
+// WriteUint64BE writes f to w as with PutUint64BE
+func (f Decimal) WriteUint64BE(w io.Writer) error {
+	return writeUint64(w, f.fp, true)
+}
+
+// WriteUint64LE writes f to w as with PutUint64LE
+func (f Decimal) WriteUint64LE(w io.Writer) error {
+	return writeUint64(w, f.fp, false)
+}
+
+// ReadUint64BE reads a Decimal written with WriteUint64BE from r
+func ReadUint64BE(r io.Reader) (Decimal, error) {
+	fp, err := readUint64(r, true)
+	if err != nil {
+		return Zero, err
+	}
+	return Decimal{fp: fp}, nil
+}
+
+// ReadUint64LE reads a Decimal written with WriteUint64LE from r
+func ReadUint64LE(r io.Reader) (Decimal, error) {
+	fp, err := readUint64(r, false)
+	if err != nil {
+		return Zero, err
+	}
+	return Decimal{fp: fp}, nil
+}
+
+// WriteUvarint writes f to w in the varint format of MarshalBinary and WriteTo
+func (f Decimal) WriteUvarint(w io.Writer) error {
+	if bw, ok := w.(io.ByteWriter); ok {
+		return writeUvarint(bw, f.fp)
+	}
+	buf := wireBuffers.Get().(*wireBuffer)
+	n := binary.PutUvarint(buf.b[:], f.fp)
+	_, err := w.Write(buf.b[:n])
+	wireBuffers.Put(buf)
+	return err
+}
+
+// ReadUvarint reads a Decimal written with WriteUvarint, WriteTo or MarshalBinary from r. Only the bytes
+// of the encoded value are read from r.
+func ReadUvarint(r io.Reader) (Decimal, error) {
+	var fp uint64
+	var err error
+	if br, ok := r.(io.ByteReader); ok {
+		fp, err = binary.ReadUvarint(br)
+	} else {
+		buf := wireBuffers.Get().(*wireBuffer)
+		buf.r = r
+		fp, err = binary.ReadUvarint(buf)
+		buf.r = nil
+		wireBuffers.Put(buf)
+	}
+	if err != nil {
+		return Zero, err
+	}
+	return Decimal{fp: fp}, nil
+}
+
+// FromMantissaExponent creates a Decimal with the value mantissa * 10^exp. It returns ErrNegative if the
+// mantissa is negative, ErrOverflow if the value is too large and ErrPrecision if the value has more than
+// 8 significant decimal places.
+func FromMantissaExponent(mantissa int64, exp int8) (Decimal, error) {
+	if mantissa < 0 {
+		return Zero, ErrNegative
+	}
+	m := uint64(mantissa)
+	if m == 0 {
+		return Zero, nil
+	}
+
+	shift := int(exp) + nPlaces
+	if shift >= 0 {
+		if shift >= len(pow10) || m > maxFP/pow10[shift] {
+			return Zero, ErrOverflow
+		}
+		return Decimal{fp: m * pow10[shift]}, nil
+	}
+	if -shift >= len(pow10) || m%pow10[-shift] != 0 {
+		return Zero, ErrPrecision
+	}
+	return Decimal{fp: m / pow10[-shift]}, nil
+}
+
+// MantissaExponent returns the mantissa and exponent of f with trailing zeros removed from the mantissa,
+// so that f = mantissa * 10^exp. Zero is returned as 0, 0. It returns ErrOverflow if the mantissa does not
+// fit in an int64, which is only possible for values above 92233720368.54775807.
+func (f Decimal) MantissaExponent() (int64, int8, error) {
+	if f.fp == 0 {
+		return 0, 0, nil
+	}
+	m, exp := f.fp, -nPlaces
+	for m%10 == 0 {
+		m /= 10
+		exp++
+	}
+	if m > math.MaxInt64 {
+		return 0, 0, ErrOverflow
+	}
+	return int64(m), int8(exp), nil
+}
+
+// Mantissa returns the mantissa of f for a fixed exponent, so that f = mantissa * 10^exp. It returns
+// ErrPrecision if f has more significant decimal places than -exp and ErrOverflow if the mantissa does not
+// fit in an int64.
+func (f Decimal) Mantissa(exp int8) (int64, error) {
+	m := f.fp
+	if m == 0 {
+		return 0, nil
+	}
+
+	shift := int(exp) + nPlaces
+	if shift >= 0 {
+		if shift >= len(pow10) || m%pow10[shift] != 0 {
+			return 0, ErrPrecision
+		}
+		m /= pow10[shift]
+	} else {
+		if -shift >= len(pow10) || m > math.MaxInt64/pow10[-shift] {
+			return 0, ErrOverflow
+		}
+		m *= pow10[-shift]
+	}
+	if m > math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return int64(m), nil
+}
+
+// wireBuffer is a pooled scratch buffer so that writing to an io.Writer or reading from an io.Reader does
+// not allocate
+type wireBuffer struct {
+	b [binary.MaxVarintLen64]byte
+	r io.Reader
+}
+
+// ReadByte implements io.ByteReader on the reader r
+func (buf *wireBuffer) ReadByte() (byte, error) {
+	_, err := io.ReadFull(buf.r, buf.b[:1])
+	return buf.b[0], err
+}
+
+var wireBuffers = sync.Pool{New: func() interface{} { return new(wireBuffer) }}
+
+func writeUint64(w io.Writer, x uint64, bigEndian bool) error {
+	if bw, ok := w.(io.ByteWriter); ok {
+		for i := 0; i < 8; i++ {
+			shift := uint(8 * i)
+			if bigEndian {
+				shift = 56 - shift
+			}
+			if err := bw.WriteByte(byte(x >> shift)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	buf := wireBuffers.Get().(*wireBuffer)
+	if bigEndian {
+		binary.BigEndian.PutUint64(buf.b[:], x)
+	} else {
+		binary.LittleEndian.PutUint64(buf.b[:], x)
+	}
+	_, err := w.Write(buf.b[:8])
+	wireBuffers.Put(buf)
+	return err
+}
+
+func readUint64(r io.Reader, bigEndian bool) (uint64, error) {
+	if br, ok := r.(io.ByteReader); ok {
+		var x uint64
+		for i := 0; i < 8; i++ {
+			c, err := br.ReadByte()
+			if err != nil {
+				if i > 0 && err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return 0, err
+			}
+			shift := uint(8 * i)
+			if bigEndian {
+				shift = 56 - shift
+			}
+			x |= uint64(c) << shift
+		}
+		return x, nil
+	}
+
+	buf := wireBuffers.Get().(*wireBuffer)
+	_, err := io.ReadFull(r, buf.b[:8])
+	var x uint64
+	if bigEndian {
+		x = binary.BigEndian.Uint64(buf.b[:])
+	} else {
+		x = binary.LittleEndian.Uint64(buf.b[:])
+	}
+	wireBuffers.Put(buf)
+	return x, err
+}
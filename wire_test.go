@@ -0,0 +1,209 @@
+package udecimal_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	. "github.com/geseq/udecimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// onlyWriter hides any io.ByteWriter or io.ByteReader implementation of the underlying buffer
+type onlyWriter struct{ w io.Writer }
+
+func (w onlyWriter) Write(p []byte) (int, error) { return w.w.Write(p) }
+
+type onlyReader struct{ r io.Reader }
+
+func (r onlyReader) Read(p []byte) (int, error) { return r.r.Read(p) }
+
+func TestPutUint64(t *testing.T) {
+	f := MustParse("1.5")
+	b := make([]byte, 8)
+
+	f.PutUint64BE(b)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0x08, 0xf0, 0xd1, 0x80}, b)
+	f0, err := DecodeUint64BE(b)
+	assert.NoError(t, err)
+	assert.Equal(t, f, f0)
+
+	f.PutUint64LE(b)
+	assert.Equal(t, []byte{0x80, 0xd1, 0xf0, 0x08, 0, 0, 0, 0}, b)
+	f0, err = DecodeUint64LE(b)
+	assert.NoError(t, err)
+	assert.Equal(t, f, f0)
+
+	_, err = DecodeUint64BE(b[:7])
+	assert.Error(t, err)
+	f0, err = DecodeUint64LE([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	assert.NoError(t, err)
+	assert.Equal(t, Max.Add(FromUnits(8446744073709551616)), f0)
+	assert.Panics(t, func() { f.PutUint64BE(b[:4]) })
+}
+
+func TestWireReadWrite(t *testing.T) {
+	rnd := rand.New(rand.NewSource(9))
+	// Add can return values above MAX, which the wire formats carry as UnmarshalBinary does
+	values := []Decimal{Zero, SmallestUnit, Max, MustParse("90000000000").Add(MustParse("90000000000")), Max.Add(FromUnits(8446744073709551616))}
+	for i := 0; i < 100; i++ {
+		values = append(values, MustParse(randomUnits(rnd).String()))
+	}
+
+	for _, buffered := range []bool{true, false} {
+		var buf bytes.Buffer
+		var w io.Writer = &buf
+		var r io.Reader = &buf
+		if !buffered {
+			w, r = onlyWriter{&buf}, onlyReader{&buf}
+		}
+
+		for _, f := range values {
+			assert.NoError(t, f.WriteUint64BE(w))
+			assert.NoError(t, f.WriteUint64LE(w))
+			assert.NoError(t, f.WriteUvarint(w))
+		}
+		for _, f := range values {
+			f0, err := ReadUint64BE(r)
+			assert.NoError(t, err)
+			assert.Equal(t, f, f0)
+			f0, err = ReadUint64LE(r)
+			assert.NoError(t, err)
+			assert.Equal(t, f, f0)
+			f0, err = ReadUvarint(r)
+			assert.NoError(t, err)
+			assert.Equal(t, f, f0)
+		}
+
+		_, err := ReadUint64BE(r)
+		assert.Equal(t, io.EOF, err)
+		_, err = ReadUvarint(r)
+		assert.Equal(t, io.EOF, err)
+
+		buf.Write([]byte{1, 2, 3})
+		_, err = ReadUint64LE(r)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, MustParse("12.5").WriteTo(&buf))
+	f, err := ReadUvarint(onlyReader{&buf})
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("12.5"), f)
+}
+
+func TestMantissaExponent(t *testing.T) {
+	tests := []struct {
+		mantissa int64
+		exp      int8
+		expected string
+	}{
+		{0, 0, "0"},
+		{0, -100, "0"},
+		{0, 100, "0"},
+		{15, -1, "1.5"},
+		{123456789, -8, "1.23456789"},
+		{12345678900, -10, "1.23456789"},
+		{5, 3, "5000"},
+		{99999999999, 0, "99999999999"},
+		{1, -8, "0.00000001"},
+		{1, 10, "10000000000"},
+	}
+
+	for _, tt := range tests {
+		f, err := FromMantissaExponent(tt.mantissa, tt.exp)
+		if assert.NoError(t, err, "%de%d", tt.mantissa, tt.exp) {
+			assert.Equal(t, tt.expected, f.String())
+		}
+	}
+
+	errs := []struct {
+		mantissa int64
+		exp      int8
+		err      error
+	}{
+		{-1, 0, ErrNegative},
+		{1, -9, ErrPrecision},
+		{123456789, -9, ErrPrecision},
+		{1, -128, ErrPrecision},
+		{1, 11, ErrOverflow},
+		{100000000000, 0, ErrOverflow},
+		{1, 127, ErrOverflow},
+	}
+
+	for _, tt := range errs {
+		_, err := FromMantissaExponent(tt.mantissa, tt.exp)
+		assert.True(t, errors.Is(err, tt.err), "%de%d: %v", tt.mantissa, tt.exp, err)
+	}
+
+	m, exp, err := MustParse("1.5").MantissaExponent()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15), m)
+	assert.Equal(t, int8(-1), exp)
+
+	m, exp, err = MustParse("1200").MantissaExponent()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), m)
+	assert.Equal(t, int8(2), exp)
+
+	m, exp, err = Zero.MantissaExponent()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), m)
+	assert.Equal(t, int8(0), exp)
+
+	_, _, err = Max.MantissaExponent()
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	m, err = MustParse("1.5").Mantissa(-4)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15000), m)
+
+	m, err = MustParse("1200").Mantissa(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), m)
+
+	_, err = MustParse("1.5").Mantissa(0)
+	assert.True(t, errors.Is(err, ErrPrecision))
+	_, err = MustParse("1").Mantissa(-19)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	_, err = Max.Mantissa(-8)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	rnd := rand.New(rand.NewSource(13))
+	for i := 0; i < 10000; i++ {
+		f := MustParse(randomUnits(rnd).String())
+		m, exp, err := f.MantissaExponent()
+		if err != nil {
+			assert.True(t, f.GreaterThan(MustParse("92233720368.54775807")))
+			continue
+		}
+		f0, err := FromMantissaExponent(m, exp)
+		assert.NoError(t, err)
+		assert.Equal(t, f, f0)
+	}
+}
+
+func TestWireAllocs(t *testing.T) {
+	f := MustParse("1234.5678")
+	var buf bytes.Buffer
+	buf.Grow(64)
+	var w io.Writer = onlyWriter{&buf}
+	var r io.Reader = onlyReader{&buf}
+	b := make([]byte, 8)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		f.PutUint64BE(b)
+		_, _ = DecodeUint64BE(b)
+		_ = f.WriteUint64BE(&buf)
+		_, _ = ReadUint64BE(&buf)
+		_ = f.WriteUint64LE(w)
+		_, _ = ReadUint64LE(r)
+		_ = f.WriteUvarint(w)
+		_, _ = ReadUvarint(r)
+		_, _ = FromMantissaExponent(15, -1)
+		_, _, _ = f.MantissaExponent()
+	})
+	assert.Equal(t, float64(0), allocs)
+}